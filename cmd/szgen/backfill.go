@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/consts"
+	"github.com/neonmei/szgen/internal/otel"
+	"github.com/neonmei/szgen/internal/runner/backfill"
+	"github.com/neonmei/szgen/internal/runner/metrictask"
+	"github.com/spf13/cobra"
+)
+
+var backfillCmd = &cobra.Command{
+	Use:     "backfill",
+	Aliases: []string{"b"},
+	Short:   "Write historical metrics of a configuration file",
+	Long: `Replay the metric tasks of a YAML configuration file over a past time range as fast as possible,
+exporting every data point with the timestamp it would have had if the run happened back then.`,
+	RunE: runBackfillCommand,
+}
+
+func init() {
+	rootCmd.AddCommand(backfillCmd)
+
+	backfillCmd.Flags().String("from", "", "Start of the range, RFC 3339 timestamp or duration ago (e.g. 24h)")
+	backfillCmd.Flags().String("to", "", "End of the range, RFC 3339 timestamp or duration ago (default now)")
+	backfillCmd.Flags().Duration("interval", consts.DefaultBackfillInterval, "Length of the export windows")
+}
+
+func runBackfillCommand(cmd *cobra.Command, _ []string) error {
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	parseReplicasFromCli(cmd, cfg)
+	parseShardFromCli(cmd, cfg)
+
+	if slices.ContainsFunc(executorFlags, cmd.Flags().Changed) {
+		slog.Warn("Backfill replays every metric task at once, executor flags are ignored")
+	}
+
+	r, err := parseBackfillRange(cmd, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return backfillConfig(cfg, r)
+}
+
+func parseBackfillRange(cmd *cobra.Command, now time.Time) (backfill.Range, error) {
+	r := backfill.Range{To: now}
+	r.Interval, _ = cmd.Flags().GetDuration("interval")
+
+	from, _ := cmd.Flags().GetString("from")
+	if from == "" {
+		return r, fmt.Errorf("no backfill start provided, see --from")
+	}
+
+	var err error
+	if r.From, err = backfill.ParseTime(from, now); err != nil {
+		return r, fmt.Errorf("invalid --from: %w", err)
+	}
+
+	if to, _ := cmd.Flags().GetString("to"); to != "" {
+		if r.To, err = backfill.ParseTime(to, now); err != nil {
+			return r, fmt.Errorf("invalid --to: %w", err)
+		}
+	}
+
+	return r, r.Validate()
+}
+
+// backfillConfig records the metric tasks of a configuration over a past range. Tasks
+// run until the end of the range regardless of their count, so they need a rate. Other
+// signals are skipped as their SDKs can't be given past timestamps.
+func backfillConfig(cfg *config.Config, r backfill.Range) error {
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	for _, task := range cfg.MetricTasks() {
+		if task.MaxSpeed() {
+			return fmt.Errorf("metric %q: backfill needs a rate, rate 0 records as fast as possible", task.Name)
+		}
+	}
+
+	if skipped := len(cfg.TraceTasks()) + len(cfg.LogTasks()) + len(cfg.ScenarioTasks()); skipped > 0 {
+		slog.Warn("Backfill only replays metric tasks, skipping traces, logs and scenarios", "skipped", skipped)
+	}
+
+	if len(cfg.Phases) > 0 {
+		slog.Warn("Backfill ignores phases, tasks keep their own generators over the whole range")
+	}
+
+	// every task records during the whole range, whatever the executor
+	expandReplicas(cfg, config.ExecutorConfig{Strategy: consts.ExecutorStrategyConcurrent})
+	expandCluster(cfg, config.ExecutorConfig{Strategy: consts.ExecutorStrategyConcurrent})
+	expandShard(cfg)
+
+	if cfg.Metrics != nil {
+		for i := range cfg.Metrics.Tasks {
+			cfg.Metrics.Tasks[i].Count = math.MaxInt
+		}
+	}
+
+	ctx, cancelFn := setupSignalHandler(context.Background())
+	defer cancelFn()
+
+	exporter, err := otel.NewBackfill(ctx, cfg, r.From)
+	if err != nil {
+		return fmt.Errorf("failed to create backfill sdk: %w", err)
+	}
+	defer func() { _ = exporter.Shutdown(context.Background()) }()
+
+	tasks, err := newMetricTasks(ctx, cfg, exporter, nil)
+	if err != nil {
+		return err
+	}
+
+	backfillers := make([]metrictask.Backfiller, 0, len(tasks))
+	for _, task := range tasks {
+		b, ok := task.(metrictask.Backfiller)
+		if !ok {
+			return fmt.Errorf("task %q can't be backfilled", task.Name())
+		}
+		backfillers = append(backfillers, b)
+	}
+
+	_, err = backfill.Run(ctx, backfillers, exporter, r)
+	return err
+}
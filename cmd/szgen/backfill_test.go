@@ -0,0 +1,26 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/runner/backfill"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackfillConfig_MaxSpeed(t *testing.T) {
+	cfg, err := config.NewConfig(
+		config.WithDefaultConfig("test"),
+		config.WithMetricsConfig(&config.MetricsConfig{Tasks: []config.MetricTask{
+			*config.NewMetricTask(config.WithName("test.requests"), config.WithType("int64"), config.WithRate(0)),
+		}}),
+	)
+	require.NoError(t, err)
+
+	now := time.Now()
+	err = backfillConfig(cfg, backfill.Range{From: now.Add(-time.Hour), To: now, Interval: time.Minute})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `metric "test.requests"`)
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/consts"
+	"github.com/neonmei/szgen/internal/otel"
+	"github.com/neonmei/szgen/internal/runner"
+	"github.com/neonmei/szgen/internal/runner/executors"
+	"github.com/spf13/cobra"
+)
+
+var logsCmd = &cobra.Command{
+	Use:     "logs",
+	Aliases: []string{"l"},
+	Short:   "Generate OpenTelemetry logs",
+	Long:    `Generate synthetic OpenTelemetry log records with templated bodies, weighted severities and a volume driven by value generators.`,
+	RunE:    runLogCommand,
+}
+
+func init() {
+	rootCmd.AddCommand(logsCmd)
+
+	logsCmd.Flags().StringToStringP("attributes", "a", nil, "Comma-separated key=value pairs")
+	logsCmd.Flags().IntP("count", "c", consts.DefaultCount, "Number of ticks emitting log records")
+	logsCmd.Flags().DurationP("rate", "r", consts.DefaultRate, "Time interval between each tick")
+	logsCmd.Flags().StringP("name", "n", consts.DefaultLogName, "Log task name")
+	logsCmd.Flags().StringP("body", "b", consts.DefaultLogBody, "Log body template (fields: .Name, .Index, .Tick, .Severity)")
+	logsCmd.Flags().StringToString("severities", nil, "Comma-separated severity=weight pairs (trace, debug, info, warn, error, fatal)")
+	logsCmd.Flags().StringP("generator", "g", consts.DefaultGenerator, "Records per tick generation pattern")
+	logsCmd.Flags().StringP("value", "v", consts.DefaultValue, "Records per tick, static value or value range")
+	logsCmd.Flags().Uint64("seed", 0, "Seed making volumes and severities reproducible (0 = random)")
+}
+
+func buildLogConfig(cmd *cobra.Command) (*config.LogTask, error) {
+	var options []config.LogTaskOption
+
+	if cmd.Flags().Changed("name") {
+		name, _ := cmd.Flags().GetString("name")
+		options = append(options, config.WithLogName(name))
+	}
+	if cmd.Flags().Changed("count") {
+		count, _ := cmd.Flags().GetInt("count")
+		options = append(options, config.WithLogCount(count))
+	}
+	if cmd.Flags().Changed("rate") {
+		rate, _ := cmd.Flags().GetDuration("rate")
+		options = append(options, config.WithLogRate(rate))
+	}
+	if cmd.Flags().Changed("body") {
+		body, _ := cmd.Flags().GetString("body")
+		options = append(options, config.WithBody(body))
+	}
+	if cmd.Flags().Changed("severities") {
+		strWeights, _ := cmd.Flags().GetStringToString("severities")
+		weights := make(map[string]float64, len(strWeights))
+		for severity, w := range strWeights {
+			weight, err := strconv.ParseFloat(w, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid weight for severity %q: %w", severity, err)
+			}
+			weights[severity] = weight
+		}
+		options = append(options, config.WithSeverities(weights))
+	}
+	if cmd.Flags().Changed("generator") {
+		generator, _ := cmd.Flags().GetString("generator")
+		options = append(options, config.WithLogGenerator(generator))
+	}
+	if cmd.Flags().Changed("value") {
+		value, _ := cmd.Flags().GetString("value")
+		options = append(options, config.WithLogValue(value))
+	}
+	if cmd.Flags().Changed("attributes") {
+		strAttrs, _ := cmd.Flags().GetStringToString("attributes")
+		attrs := make(map[string]any, len(strAttrs))
+		for k, v := range strAttrs {
+			attrs[k] = v
+		}
+		options = append(options, config.WithLogAttributes(attrs))
+	}
+	if cmd.Flags().Changed("seed") {
+		seed, _ := cmd.Flags().GetUint64("seed")
+		options = append(options, config.WithLogSeed(seed))
+	}
+
+	lc := config.NewLogTask(options...)
+
+	if err := lc.Validate(); err != nil {
+		return nil, err
+	}
+
+	slog.Debug("loaded config",
+		"log", lc.Name,
+		"rate", lc.Rate,
+		"count", lc.Count,
+		"body", lc.Body,
+		"severities", lc.Severities,
+		"value", lc.Value,
+		"generator", lc.Generator,
+		"attributes", lc.Attributes,
+		"seed", lc.Seed,
+	)
+	return lc, nil
+}
+
+func runLogCommand(cmd *cobra.Command, _ []string) error {
+	cfg, err := config.NewConfig(config.WithDefaultConfig(version), config.WithOtelConfigFile())
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	logCfg, err := buildLogConfig(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to build log config: %w", err)
+	}
+
+	ctx, cancelFn := setupSignalHandler(context.Background())
+	defer cancelFn()
+
+	// Append CLI task to config
+	cfg.Logs.Tasks = append(cfg.Logs.Tasks, *logCfg)
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	executorConfig, err := parseExecutorConfigFromCli(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to parse executor config: %w", err)
+	}
+
+	sdk, err := otel.NewSDK(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create sdk: %w", err)
+	}
+
+	if err := sdk.Start(); err != nil {
+		return fmt.Errorf("failed to start sdk: %w", err)
+	}
+	defer func() { _ = sdk.Shutdown(ctx) }()
+
+	tasks, err := newLogTasks(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	report := runner.NewReport()
+	exec, err := executors.New(*executorConfig, executors.WithReport(report))
+	if err != nil {
+		return fmt.Errorf("failed to create executor: %w", err)
+	}
+
+	opts := parseRunOptionsFromCli(cmd)
+	tasks, stopProgress := startProgress(tasks, opts.progress)
+	err = exec.Execute(ctx, tasks)
+	stopProgress()
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Flushing logs")
+	flushCtx, cancel := context.WithTimeout(context.Background(), consts.DefaultFlushTimeout)
+	defer cancel()
+
+	if err := sdk.ForceFlush(flushCtx); err != nil {
+		slog.Warn("Failed to flush logs", "error", err)
+	}
+
+	return writeReport(report, opts.reportPath)
+}
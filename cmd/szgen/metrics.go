@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 
@@ -10,7 +11,6 @@ import (
 	"github.com/neonmei/szgen/internal/otel"
 	"github.com/neonmei/szgen/internal/runner"
 	"github.com/neonmei/szgen/internal/runner/executors"
-	"github.com/neonmei/szgen/internal/runner/metrictask"
 	"github.com/spf13/cobra"
 )
 
@@ -26,13 +26,16 @@ func init() {
 
 	metricsCmd.PersistentFlags().StringP("attributes", "a", "", "Comma-separated key=value pairs")
 	metricsCmd.PersistentFlags().IntP("count", "c", consts.DefaultCount, "Number of data points to generate")
-	metricsCmd.PersistentFlags().DurationP("rate", "r", consts.DefaultRate, "Time interval between each generated data point")
+	metricsCmd.PersistentFlags().DurationP("rate", "r", consts.DefaultRate, "Time interval between each generated data point (0 = as fast as possible)")
 	metricsCmd.PersistentFlags().StringP("description", "d", consts.DefaultDescription, "Metric description")
 	metricsCmd.PersistentFlags().StringP("name", "n", consts.DefaultMetricName, "Metric name")
 	metricsCmd.PersistentFlags().StringP("unit", "u", "", "Metric unit")
 	metricsCmd.PersistentFlags().StringP("generator", "g", consts.DefaultGenerator, "Value generation pattern")
 	metricsCmd.PersistentFlags().StringP("value", "v", consts.DefaultValue, "Static value or value range")
 	metricsCmd.PersistentFlags().StringP("type", "t", "", "Value type (int64, float64) - smart defaults: counter=int64, others=float64")
+	metricsCmd.PersistentFlags().Bool("emit-immediately", false, "Record the first data point right away instead of one interval later")
+	metricsCmd.PersistentFlags().String("jitter", "", "Random delay of every data point, a duration or a percentage of the rate (e.g. 10%)")
+	metricsCmd.PersistentFlags().Duration("align", 0, "Start on a wall-clock boundary, e.g. 1m to start on the minute")
 }
 
 func runMetricCommand(cmd *cobra.Command, metricType string) error {
@@ -51,16 +54,22 @@ func runMetricCommand(cmd *cobra.Command, metricType string) error {
 
 	// Append CLI task to config
 	cfg.Metrics.Tasks = append(cfg.Metrics.Tasks, *metricCfg)
+	parseReplicasFromCli(cmd, cfg)
+	parseShardFromCli(cmd, cfg)
+	parseMaxSpeedFromCli(cmd, cfg)
 
 	if err := cfg.Validate(); err != nil {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
-	task, err := metrictask.New(ctx, *metricCfg)
+	executorConfig, err := parseExecutorConfigFromCli(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to create task: %w", err)
+		return fmt.Errorf("failed to parse executor config: %w", err)
 	}
 
+	expandReplicas(cfg, *executorConfig)
+	expandShard(cfg)
+
 	sdk, err := otel.NewSDK(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to create sdk: %w", err)
@@ -71,27 +80,34 @@ func runMetricCommand(cmd *cobra.Command, metricType string) error {
 	}
 	defer func() { _ = sdk.Shutdown(ctx) }()
 
-	executorConfig, err := parseExecutorConfigFromCli(cmd)
+	tasks, err := newMetricTasks(ctx, cfg, sdk, nil)
 	if err != nil {
-		return fmt.Errorf("failed to parse executor config: %w", err)
+		return err
 	}
 
-	exec, err := executors.New(*executorConfig)
+	bench, tasks := newBenchmark(cfg, tasks)
+
+	report := runner.NewReport()
+	exec, err := executors.New(*executorConfig, executors.WithReport(report))
 	if err != nil {
 		return fmt.Errorf("failed to create executor: %w", err)
 	}
 
-	if err := exec.Execute(ctx, []runner.Task{task}); err != nil {
-		return err
-	}
+	opts := parseRunOptionsFromCli(cmd)
+	tasks, stopProgress := startProgress(tasks, opts.progress)
+	execErr := exec.Execute(ctx, tasks)
+	stopProgress()
 
+	// Failed tasks don't discard the data points recorded so far nor the report
 	slog.Info("Flushing metrics")
 	flushCtx, cancel := context.WithTimeout(context.Background(), consts.DefaultFlushTimeout)
 	defer cancel()
 
 	if err := sdk.ForceFlush(flushCtx); err != nil {
 		slog.Warn("Failed to flush metrics", "error", err)
+		bench.Handle(err)
 	}
+	bench.Report()
 
-	return nil
+	return errors.Join(execErr, writeReport(report, opts.reportPath))
 }
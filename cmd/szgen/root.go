@@ -26,15 +26,113 @@ func Execute() error {
 }
 
 func init() {
-	rootCmd.PersistentFlags().StringP("executor", "e", consts.DefaultExecutorStrategy, "Executor strategy (serial, concurrent)")
-	rootCmd.PersistentFlags().IntP("max-concurrency", "j", 0, "Maximum concurrency for concurrent executor (0 = unlimited), only applies to concurrent executor")
+	rootCmd.PersistentFlags().StringP("executor", "e", consts.DefaultExecutorStrategy, "Executor strategy (serial, concurrent, dag, ramp)")
+	rootCmd.PersistentFlags().IntP("max-concurrency", "j", 0, "Maximum concurrency for concurrent and dag executors (0 = unlimited)")
+	rootCmd.PersistentFlags().String("failure-policy", consts.DefaultFailurePolicy, "What to do when a task fails (fail_fast, continue, retry)")
+	rootCmd.PersistentFlags().String("repeat", "", "Run the task list this many times, or infinite to loop until interrupted")
+	rootCmd.PersistentFlags().Duration("pause-between", 0, "Pause between repeated runs of the task list")
+	rootCmd.PersistentFlags().Float64("max-points-per-second", 0, "Data points per second shared by every task (0 = unlimited)")
+	rootCmd.PersistentFlags().Float64("time-scale", 1, "Run the clock this many times faster than the wall clock, e.g. 60 runs an hour in a minute")
+	rootCmd.PersistentFlags().Bool("max-speed", false, "Record metric points as fast as possible and report the throughput achieved")
+	rootCmd.PersistentFlags().Int("replicas", 0, "Number of simulated service instances running every task (0 = use config)")
+	rootCmd.PersistentFlags().Int("shard-index", 0, "Index of this process among the shards of the run, from 0")
+	rootCmd.PersistentFlags().Int("shard-count", 0, "Number of processes splitting the tasks of the run between them, whole tasks rather than their series (0 = no sharding)")
+	rootCmd.PersistentFlags().String("report", "", "Write the run report, per task and per series, to a JSON file")
+	rootCmd.PersistentFlags().Bool("progress", false, "Display the progress of every task, live on a terminal or as periodic logs otherwise")
 	rootCmd.PersistentFlags().String("log-level", "info", "Log level (debug, info, warn, error)")
 	rootCmd.PersistentFlags().String("log-format", "text", "Log format (text, json)")
 }
 
+func parseReplicasFromCli(cmd *cobra.Command, cfg *config.Config) {
+	if replicas, _ := cmd.Flags().GetInt("replicas"); replicas > 0 {
+		cfg.Replicas.Count = replicas
+	}
+}
+
+// runOptions are the settings of a run that don't belong to its configuration.
+type runOptions struct {
+	reportPath string
+	progress   bool
+}
+
+func parseRunOptionsFromCli(cmd *cobra.Command) runOptions {
+	var opts runOptions
+	opts.reportPath, _ = cmd.Flags().GetString("report")
+	opts.progress, _ = cmd.Flags().GetBool("progress")
+
+	return opts
+}
+
+// parseShardFromCli keeps the share of the tasks of this process, see Config.ExpandShard.
+func parseShardFromCli(cmd *cobra.Command, cfg *config.Config) {
+	cfg.Shard.Index, _ = cmd.Flags().GetInt("shard-index")
+	cfg.Shard.Count, _ = cmd.Flags().GetInt("shard-count")
+}
+
+// parseTimeScaleFromCli compresses the run of a configuration file, whatever its executor.
+func parseTimeScaleFromCli(cmd *cobra.Command, cfg *config.Config) {
+	if cmd.Flags().Changed("time-scale") {
+		cfg.Executor.TimeScale, _ = cmd.Flags().GetFloat64("time-scale")
+	}
+}
+
+// parseFailurePolicyFromCli overrides the failure policy mode of a configuration file,
+// keeping its retry settings.
+func parseFailurePolicyFromCli(cmd *cobra.Command, cfg *config.Config) {
+	if cmd.Flags().Changed("failure-policy") {
+		cfg.Executor.FailurePolicy.Mode, _ = cmd.Flags().GetString("failure-policy")
+	}
+}
+
+// parseRepeatFromCli overrides how many times the task list of a configuration file runs
+// and the pause between its runs.
+func parseRepeatFromCli(cmd *cobra.Command, cfg *config.Config) error {
+	if cmd.Flags().Changed("repeat") {
+		repeat, _ := cmd.Flags().GetString("repeat")
+		r, err := config.ParseRepeat(repeat)
+		if err != nil {
+			return err
+		}
+		cfg.Executor.Repeat = r
+	}
+
+	if cmd.Flags().Changed("pause-between") {
+		cfg.Executor.PauseBetween, _ = cmd.Flags().GetDuration("pause-between")
+	}
+
+	return nil
+}
+
+// parseMaxPointsPerSecondFromCli overrides the data points budget of a configuration file.
+func parseMaxPointsPerSecondFromCli(cmd *cobra.Command, cfg *config.Config) {
+	if !cmd.Flags().Changed("max-points-per-second") {
+		return
+	}
+
+	if cfg.Executor.Params == nil {
+		cfg.Executor.Params = make(map[string]any)
+	}
+	cfg.Executor.Params[consts.ParamMaxPointsPerSecond], _ = cmd.Flags().GetFloat64("max-points-per-second")
+}
+
+// parseMaxSpeedFromCli turns the run into a benchmark, every metric task ignoring its rate.
+func parseMaxSpeedFromCli(cmd *cobra.Command, cfg *config.Config) {
+	if maxSpeed, _ := cmd.Flags().GetBool("max-speed"); maxSpeed {
+		cfg.SetMaxSpeed()
+	}
+}
+
+// executorFlags are the flags parseExecutorConfigFromCli reads.
+var executorFlags = []string{"executor", "max-concurrency", "failure-policy", "repeat", "pause-between", "max-points-per-second", "time-scale"}
+
 func parseExecutorConfigFromCli(cmd *cobra.Command) (*config.ExecutorConfig, error) {
 	strategy, _ := cmd.Flags().GetString("executor")
 	maxConcurrency, _ := cmd.Flags().GetInt("max-concurrency")
+	maxPointsPerSecond, _ := cmd.Flags().GetFloat64("max-points-per-second")
+	failurePolicy, _ := cmd.Flags().GetString("failure-policy")
+	repeat, _ := cmd.Flags().GetString("repeat")
+	pauseBetween, _ := cmd.Flags().GetDuration("pause-between")
+	timeScale, _ := cmd.Flags().GetFloat64("time-scale")
 
 	// Create a new config to ensure defaults
 	ec := config.NewExecutorConfig()
@@ -43,6 +141,20 @@ func parseExecutorConfigFromCli(cmd *cobra.Command) (*config.ExecutorConfig, err
 		ec.Strategy = strategy
 	}
 
+	if failurePolicy != "" {
+		ec.FailurePolicy = config.FailurePolicy{Mode: failurePolicy}
+	}
+
+	if repeat != "" {
+		r, err := config.ParseRepeat(repeat)
+		if err != nil {
+			return nil, err
+		}
+		ec.Repeat = r
+	}
+	ec.PauseBetween = pauseBetween
+	ec.TimeScale = timeScale
+
 	if maxConcurrency > 0 {
 		if ec.Params == nil {
 			ec.Params = make(map[string]any)
@@ -50,6 +162,13 @@ func parseExecutorConfigFromCli(cmd *cobra.Command) (*config.ExecutorConfig, err
 		ec.Params[consts.ParamMaxConcurrency] = maxConcurrency
 	}
 
+	if maxPointsPerSecond > 0 {
+		if ec.Params == nil {
+			ec.Params = make(map[string]any)
+		}
+		ec.Params[consts.ParamMaxPointsPerSecond] = maxPointsPerSecond
+	}
+
 	if err := ec.Validate(); err != nil {
 		return nil, err
 	}
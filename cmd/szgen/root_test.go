@@ -0,0 +1,105 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/consts"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFailurePolicyFromCli(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want config.FailurePolicy
+	}{
+		{name: "unset keeps config", want: config.FailurePolicy{Mode: "retry", MaxAttempts: 5}},
+		{name: "set overrides mode", args: []string{"--failure-policy", "continue"}, want: config.FailurePolicy{Mode: "continue", MaxAttempts: 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().String("failure-policy", "fail_fast", "")
+			require.NoError(t, cmd.ParseFlags(tt.args))
+
+			cfg := &config.Config{Executor: config.ExecutorConfig{FailurePolicy: config.FailurePolicy{Mode: "retry", MaxAttempts: 5}}}
+			parseFailurePolicyFromCli(cmd, cfg)
+			assert.Equal(t, tt.want, cfg.Executor.FailurePolicy)
+		})
+	}
+}
+
+func TestParseRepeatFromCli(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		wantCount config.Repeat
+		wantPause time.Duration
+		wantErr   bool
+	}{
+		{name: "unset keeps config", wantCount: 3, wantPause: time.Second},
+		{name: "count", args: []string{"--repeat", "5"}, wantCount: 5, wantPause: time.Second},
+		{name: "infinite with pause", args: []string{"--repeat", "infinite", "--pause-between", "10s"}, wantCount: config.InfiniteRepeat, wantPause: 10 * time.Second},
+		{name: "invalid", args: []string{"--repeat", "often"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().String("repeat", "", "")
+			cmd.Flags().Duration("pause-between", 0, "")
+			require.NoError(t, cmd.ParseFlags(tt.args))
+
+			cfg := &config.Config{Executor: config.ExecutorConfig{Repeat: 3, PauseBetween: time.Second}}
+			err := parseRepeatFromCli(cmd, cfg)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCount, cfg.Executor.Repeat)
+			assert.Equal(t, tt.wantPause, cfg.Executor.PauseBetween)
+		})
+	}
+}
+
+func TestParseMaxPointsPerSecondFromCli(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want any
+	}{
+		{name: "unset keeps config", want: 100},
+		{name: "set overrides config", args: []string{"--max-points-per-second", "2500"}, want: 2500.0},
+		{name: "zero lifts the budget", args: []string{"--max-points-per-second", "0"}, want: 0.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().Float64("max-points-per-second", 0, "")
+			require.NoError(t, cmd.ParseFlags(tt.args))
+
+			cfg := &config.Config{Executor: config.ExecutorConfig{Params: map[string]any{consts.ParamMaxPointsPerSecond: 100}}}
+			parseMaxPointsPerSecondFromCli(cmd, cfg)
+			assert.Equal(t, tt.want, cfg.Executor.Params[consts.ParamMaxPointsPerSecond])
+		})
+	}
+}
+
+func TestScenarioCommand(t *testing.T) {
+	for _, name := range []string{"scenario", "s", "preset"} {
+		t.Run(name, func(t *testing.T) {
+			cmd, _, err := rootCmd.Find([]string{name, "http-server"})
+			require.NoError(t, err)
+			assert.Equal(t, "http-server", cmd.Name())
+			assert.Equal(t, scenarioCmd, cmd.Parent())
+		})
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 
@@ -10,7 +11,7 @@ import (
 	"github.com/neonmei/szgen/internal/otel"
 	"github.com/neonmei/szgen/internal/runner"
 	"github.com/neonmei/szgen/internal/runner/executors"
-	"github.com/neonmei/szgen/internal/runner/metrictask"
+	"github.com/neonmei/szgen/internal/runner/phases"
 	"github.com/spf13/cobra"
 )
 
@@ -18,7 +19,7 @@ var runCmd = &cobra.Command{
 	Use:     "run",
 	Aliases: []string{"r"},
 	Short:   "Execute configuration file",
-	Long:    `Execute a YAML configuration file with multiple metric generation tasks.`,
+	Long:    `Execute a YAML configuration file with multiple metric, trace, log and scenario generation tasks.`,
 	RunE:    runConfigFile,
 }
 
@@ -51,30 +52,33 @@ func runConfigFile(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	parseReplicasFromCli(cmd, cfg)
+	parseShardFromCli(cmd, cfg)
+	parseMaxSpeedFromCli(cmd, cfg)
+	parseTimeScaleFromCli(cmd, cfg)
+	parseFailurePolicyFromCli(cmd, cfg)
+	parseMaxPointsPerSecondFromCli(cmd, cfg)
+	if err := parseRepeatFromCli(cmd, cfg); err != nil {
+		return fmt.Errorf("invalid --repeat: %w", err)
+	}
+
+	return executeConfig(cfg, parseRunOptionsFromCli(cmd))
+}
+
+// executeConfig runs every task of a configuration through its executor, then reports
+// what they recorded.
+func executeConfig(cfg *config.Config, opts runOptions) error {
 	if err := cfg.Validate(); err != nil {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	expandReplicas(cfg, cfg.Executor)
+	expandCluster(cfg, cfg.Executor)
+	expandShard(cfg)
+
 	ctx, cancelFn := setupSignalHandler(context.Background())
 	defer cancelFn()
 
-	tasks := make([]runner.Task, 0, len(cfg.Metrics.Tasks))
-	for i, metricCfg := range cfg.Metrics.Tasks {
-		slog.Info("Queued task",
-			"metric", metricCfg.Name,
-			"generator", metricCfg.Generator,
-			"kind", metricCfg.Kind,
-			"type", metricCfg.Type,
-		)
-
-		task, err := metrictask.New(ctx, metricCfg)
-		if err != nil {
-			return fmt.Errorf("failed to create task %d: %w", i+1, err)
-		}
-
-		tasks = append(tasks, task)
-	}
-
 	sdk, err := otel.NewSDK(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to create sdk: %w", err)
@@ -85,25 +89,61 @@ func runConfigFile(cmd *cobra.Command, _ []string) error {
 	}
 	defer func() { _ = sdk.Shutdown(ctx) }()
 
-	slog.Debug("Loaded configuration", "task_count", len(cfg.Metrics.Tasks))
+	timeline := phases.New(cfg.Phases)
+	tasks, err := newMetricTasks(ctx, cfg, sdk, timeline)
+	if err != nil {
+		return err
+	}
 
-	exec, err := executors.New(cfg.Executor)
+	traceTasks, err := newTraceTasks(ctx, cfg)
 	if err != nil {
-		return fmt.Errorf("failed to create executor: %w", err)
+		return err
+	}
+	tasks = append(tasks, traceTasks...)
+
+	logTasks, err := newLogTasks(ctx, cfg)
+	if err != nil {
+		return err
 	}
+	tasks = append(tasks, logTasks...)
 
-	if err := exec.Execute(ctx, tasks); err != nil {
+	scenarioTasks, err := newScenarioTasks(ctx, cfg, sdk)
+	if err != nil {
 		return err
 	}
+	tasks = append(tasks, scenarioTasks...)
+
+	slog.Debug("Loaded configuration", "task_count", len(tasks))
+
+	bench, tasks := newBenchmark(cfg, tasks)
+
+	report := runner.NewReport()
+	execOpts := []executors.Option{executors.WithReport(report)}
+	if timeline != nil {
+		// every iteration of a repeated run goes through the phases again
+		execOpts = append(execOpts, executors.WithIterationHook(timeline.Start))
+	}
+
+	exec, err := executors.New(cfg.Executor, execOpts...)
+	if err != nil {
+		return fmt.Errorf("failed to create executor: %w", err)
+	}
+
+	tasks, stopProgress := startProgress(tasks, opts.progress)
+	execErr := exec.Execute(ctx, tasks)
+	stopProgress()
 
-	// Force flush metrics before shutdown
-	slog.Info("Flushing metrics")
+	// Force flush telemetry before shutdown, tasks that succeeded under the continue
+	// and retry failure policies still deliver their data
+	slog.Info("Flushing telemetry")
 	flushCtx, cancel := context.WithTimeout(context.Background(), consts.DefaultFlushTimeout)
 	defer cancel()
 
 	if err := sdk.ForceFlush(flushCtx); err != nil {
-		slog.Warn("Failed to flush metrics", "error", err)
+		slog.Warn("Failed to flush telemetry", "error", err)
+		bench.Handle(err)
 	}
+	bench.Report()
 
-	return nil
+	return errors.Join(execErr, writeReport(report, opts.reportPath))
 }
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/consts"
+	"github.com/neonmei/szgen/internal/presets"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var scenarioCmd = &cobra.Command{
+	Use:     "scenario",
+	Aliases: []string{"s", "preset"},
+	Short:   "Generate semantic convention compliant telemetry presets",
+	Long: `Generate telemetry for common workloads following the OpenTelemetry semantic conventions.
+Presets can be expanded into a plain szgen configuration file with --expand to customise them.`,
+}
+
+func init() {
+	rootCmd.AddCommand(scenarioCmd)
+
+	scenarioCmd.PersistentFlags().String("service", "", "Service name the preset telemetry is reported under")
+	scenarioCmd.PersistentFlags().DurationP("rate", "r", consts.DefaultRate, "Time interval between requests or samples")
+	scenarioCmd.PersistentFlags().IntP("count", "c", consts.DefaultPresetCount, "Number of requests or samples to generate")
+	scenarioCmd.PersistentFlags().Float64("error-ratio", 0, "Ratio of failed requests (0-1)")
+	scenarioCmd.PersistentFlags().String("latency", consts.DefaultLatencyProfile, "Latency profile (fast, normal, slow)")
+	scenarioCmd.PersistentFlags().Bool("expand", false, "Print the preset as a szgen configuration file instead of running it")
+
+	for _, name := range presets.Names() {
+		scenarioCmd.AddCommand(&cobra.Command{
+			Use:   name,
+			Short: presets.Description(name),
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				return runPreset(cmd, name)
+			},
+		})
+	}
+}
+
+func buildPresetConfig(cmd *cobra.Command, name string) (*config.Config, error) {
+	service, _ := cmd.Flags().GetString("service")
+	rate, _ := cmd.Flags().GetDuration("rate")
+	count, _ := cmd.Flags().GetInt("count")
+	errorRatio, _ := cmd.Flags().GetFloat64("error-ratio")
+	latency, _ := cmd.Flags().GetString("latency")
+
+	return presets.New(name,
+		presets.WithService(service),
+		presets.WithRate(rate),
+		presets.WithCount(count),
+		presets.WithErrorRatio(errorRatio),
+		presets.WithLatency(latency),
+	)
+}
+
+func runPreset(cmd *cobra.Command, name string) error {
+	preset, err := buildPresetConfig(cmd, name)
+	if err != nil {
+		return fmt.Errorf("failed to build preset: %w", err)
+	}
+
+	if expand, _ := cmd.Flags().GetBool("expand"); expand {
+		enc := yaml.NewEncoder(os.Stdout)
+		enc.SetIndent(2)
+		defer enc.Close()
+
+		return enc.Encode(preset)
+	}
+
+	cfg, err := config.NewConfig(config.WithDefaultConfig(version), config.WithOtelConfigFile())
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	cfg.Metrics = preset.Metrics
+	cfg.Services = preset.Services
+	cfg.Executor = preset.Executor
+
+	if slices.ContainsFunc(executorFlags, cmd.Flags().Changed) {
+		executorConfig, err := parseExecutorConfigFromCli(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to parse executor config: %w", err)
+		}
+		cfg.Executor = *executorConfig
+	}
+
+	parseReplicasFromCli(cmd, cfg)
+	parseShardFromCli(cmd, cfg)
+	parseMaxSpeedFromCli(cmd, cfg)
+
+	return executeConfig(cfg, parseRunOptionsFromCli(cmd))
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/consts"
+	"github.com/neonmei/szgen/internal/otel"
+	"github.com/neonmei/szgen/internal/runner"
+	"github.com/neonmei/szgen/internal/runner/executors"
+	"github.com/spf13/cobra"
+)
+
+var tracesCmd = &cobra.Command{
+	Use:     "traces",
+	Aliases: []string{"t"},
+	Short:   "Generate OpenTelemetry traces",
+	Long:    `Generate synthetic OpenTelemetry traces made of span trees with configurable depth, fan-out, span kinds, durations and error ratio.`,
+	RunE:    runTraceCommand,
+}
+
+func init() {
+	rootCmd.AddCommand(tracesCmd)
+
+	tracesCmd.Flags().StringToStringP("attributes", "a", nil, "Comma-separated key=value pairs")
+	tracesCmd.Flags().IntP("count", "c", consts.DefaultCount, "Number of traces to generate")
+	tracesCmd.Flags().DurationP("rate", "r", consts.DefaultRate, "Time interval between each generated trace")
+	tracesCmd.Flags().StringP("name", "n", consts.DefaultSpanName, "Root span name")
+	tracesCmd.Flags().Int("depth", consts.DefaultSpanDepth, "Levels of spans in every trace")
+	tracesCmd.Flags().Int("fan-out", consts.DefaultSpanFanOut, "Children of every non-leaf span")
+	tracesCmd.Flags().StringSlice("span-kinds", nil, "Span kind per level (server, client, producer, consumer, internal)")
+	tracesCmd.Flags().StringP("generator", "g", consts.DefaultGenerator, "Span duration generation pattern")
+	tracesCmd.Flags().StringP("value", "v", consts.DefaultSpanDuration, "Span duration in milliseconds, static value or value range")
+	tracesCmd.Flags().Float64("error-ratio", 0, "Ratio of spans with error status (0-1)")
+	tracesCmd.Flags().String("status-codes", "", "Comma-separated status codes cycled over spans, recorded as http.response.status_code")
+	tracesCmd.Flags().Uint64("seed", 0, "Seed making durations, statuses and IDs reproducible (0 = random)")
+}
+
+func buildTraceConfig(cmd *cobra.Command) (*config.TraceTask, error) {
+	var options []config.TraceTaskOption
+
+	if cmd.Flags().Changed("name") {
+		name, _ := cmd.Flags().GetString("name")
+		options = append(options, config.WithSpanName(name))
+	}
+	if cmd.Flags().Changed("count") {
+		count, _ := cmd.Flags().GetInt("count")
+		options = append(options, config.WithTraceCount(count))
+	}
+	if cmd.Flags().Changed("rate") {
+		rate, _ := cmd.Flags().GetDuration("rate")
+		options = append(options, config.WithTraceRate(rate))
+	}
+	if cmd.Flags().Changed("depth") {
+		depth, _ := cmd.Flags().GetInt("depth")
+		options = append(options, config.WithDepth(depth))
+	}
+	if cmd.Flags().Changed("fan-out") {
+		fanOut, _ := cmd.Flags().GetInt("fan-out")
+		options = append(options, config.WithFanOut(fanOut))
+	}
+	if cmd.Flags().Changed("span-kinds") {
+		kinds, _ := cmd.Flags().GetStringSlice("span-kinds")
+		options = append(options, config.WithSpanKinds(kinds))
+	}
+	if cmd.Flags().Changed("generator") {
+		generator, _ := cmd.Flags().GetString("generator")
+		options = append(options, config.WithTraceGenerator(generator))
+	}
+	if cmd.Flags().Changed("value") {
+		value, _ := cmd.Flags().GetString("value")
+		options = append(options, config.WithTraceValue(value))
+	}
+	var model config.SpanModel
+	if cmd.Flags().Changed("error-ratio") {
+		model.ErrorRatio, _ = cmd.Flags().GetFloat64("error-ratio")
+	}
+	if cmd.Flags().Changed("status-codes") {
+		statusCodes, _ := cmd.Flags().GetString("status-codes")
+		model.Status = &config.SpanStatus{
+			Generator: consts.GeneratorSequence,
+			Value:     statusCodes,
+			Attribute: consts.DefaultStatusAttribute,
+		}
+	}
+	if cmd.Flags().Changed("seed") {
+		model.Seed, _ = cmd.Flags().GetUint64("seed")
+	}
+	options = append(options, config.WithSpanModel(model))
+	if cmd.Flags().Changed("attributes") {
+		strAttrs, _ := cmd.Flags().GetStringToString("attributes")
+		attrs := make(map[string]any, len(strAttrs))
+		for k, v := range strAttrs {
+			attrs[k] = v
+		}
+		options = append(options, config.WithTraceAttributes(attrs))
+	}
+
+	tc := config.NewTraceTask(options...)
+
+	if err := tc.Validate(); err != nil {
+		return nil, err
+	}
+
+	slog.Debug("loaded config",
+		"trace", tc.Name,
+		"rate", tc.Rate,
+		"count", tc.Count,
+		"depth", tc.Depth,
+		"fan_out", tc.FanOut,
+		"span_kinds", tc.SpanKinds,
+		"value", tc.Value,
+		"generator", tc.Generator,
+		"error_ratio", tc.ErrorRatio,
+		"seed", tc.Seed,
+		"attributes", tc.Attributes,
+	)
+	return tc, nil
+}
+
+func runTraceCommand(cmd *cobra.Command, _ []string) error {
+	cfg, err := config.NewConfig(config.WithDefaultConfig(version), config.WithOtelConfigFile())
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	traceCfg, err := buildTraceConfig(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to build trace config: %w", err)
+	}
+
+	ctx, cancelFn := setupSignalHandler(context.Background())
+	defer cancelFn()
+
+	// Append CLI task to config
+	cfg.Traces.Tasks = append(cfg.Traces.Tasks, *traceCfg)
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	executorConfig, err := parseExecutorConfigFromCli(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to parse executor config: %w", err)
+	}
+
+	sdk, err := otel.NewSDK(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create sdk: %w", err)
+	}
+
+	if err := sdk.Start(); err != nil {
+		return fmt.Errorf("failed to start sdk: %w", err)
+	}
+	defer func() { _ = sdk.Shutdown(ctx) }()
+
+	tasks, err := newTraceTasks(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	report := runner.NewReport()
+	exec, err := executors.New(*executorConfig, executors.WithReport(report))
+	if err != nil {
+		return fmt.Errorf("failed to create executor: %w", err)
+	}
+
+	opts := parseRunOptionsFromCli(cmd)
+	tasks, stopProgress := startProgress(tasks, opts.progress)
+	err = exec.Execute(ctx, tasks)
+	stopProgress()
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Flushing traces")
+	flushCtx, cancel := context.WithTimeout(context.Background(), consts.DefaultFlushTimeout)
+	defer cancel()
+
+	if err := sdk.ForceFlush(flushCtx); err != nil {
+		slog.Warn("Failed to flush traces", "error", err)
+	}
+
+	return writeReport(report, opts.reportPath)
+}
@@ -2,13 +2,25 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
 
 	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/consts"
+	"github.com/neonmei/szgen/internal/logging"
+	"github.com/neonmei/szgen/internal/otel"
+	"github.com/neonmei/szgen/internal/runner"
+	"github.com/neonmei/szgen/internal/runner/logtask"
+	"github.com/neonmei/szgen/internal/runner/metrictask"
+	"github.com/neonmei/szgen/internal/runner/phases"
+	"github.com/neonmei/szgen/internal/runner/progress"
+	"github.com/neonmei/szgen/internal/runner/scenario"
+	"github.com/neonmei/szgen/internal/runner/tracetask"
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/metric"
 )
 
 func buildMetricConfig(cmd *cobra.Command, metricType string) (*config.MetricTask, error) {
@@ -56,6 +68,12 @@ func buildMetricConfig(cmd *cobra.Command, metricType string) (*config.MetricTas
 		}
 		options = append(options, config.WithMetricAttributes(attrs))
 	}
+	if cmd.Flags().Changed("emit-immediately") || cmd.Flags().Changed("jitter") || cmd.Flags().Changed("align") {
+		emitImmediately, _ := cmd.Flags().GetBool("emit-immediately")
+		jitter, _ := cmd.Flags().GetString("jitter")
+		align, _ := cmd.Flags().GetDuration("align")
+		options = append(options, config.WithSchedule(emitImmediately, jitter, align))
+	}
 
 	mc := config.NewMetricTask(options...)
 
@@ -78,6 +96,262 @@ func buildMetricConfig(cmd *cobra.Command, metricType string) (*config.MetricTas
 	return mc, nil
 }
 
+// expandReplicas instantiates the configured tasks once per replica.
+func expandReplicas(cfg *config.Config, executor config.ExecutorConfig) {
+	if !cfg.Replicas.Enabled() {
+		return
+	}
+
+	if executor.Strategy == consts.ExecutorStrategySerial {
+		slog.Warn("Replicas run one after another with the serial executor, consider --executor concurrent",
+			"replicas", cfg.Replicas.Count,
+		)
+	}
+
+	cfg.ExpandReplicas()
+	slog.Info("Expanded replicas",
+		"replicas", cfg.Replicas.Count,
+		"tasks", len(cfg.MetricTasks()),
+		"share_exporter", cfg.Replicas.ShareExporter,
+	)
+}
+
+// expandCluster adds the services and per pod tasks of the simulated cluster.
+func expandCluster(cfg *config.Config, executor config.ExecutorConfig) {
+	if cfg.Cluster == nil {
+		return
+	}
+
+	if executor.Strategy == consts.ExecutorStrategySerial {
+		slog.Warn("Cluster pods run one after another with the serial executor, consider --executor concurrent")
+	}
+
+	cfg.ExpandCluster()
+	slog.Info("Expanded cluster",
+		"nodes", cfg.Cluster.Nodes,
+		"deployments", len(cfg.Cluster.Services()),
+		"pods", cfg.Cluster.Namespaces*cfg.Cluster.Deployments*cfg.Cluster.Pods,
+		"restarts", cfg.Cluster.Restarts,
+		"tasks", len(cfg.MetricTasks()),
+	)
+}
+
+// expandShard drops the tasks other processes of a sharded run take care of.
+func expandShard(cfg *config.Config) {
+	if !cfg.Shard.Enabled() {
+		return
+	}
+
+	cfg.ExpandShard()
+	tasks := len(cfg.MetricTasks()) + len(cfg.TraceTasks()) + len(cfg.LogTasks()) + len(cfg.ScenarioTasks())
+	slog.Info("Sharded tasks",
+		"shard_index", cfg.Shard.Index,
+		"shard_count", cfg.Shard.Count,
+		"tasks", tasks,
+	)
+
+	if tasks == 0 {
+		slog.Warn("No tasks left to this shard, there are more shards than tasks to split")
+	}
+}
+
+// writeReport logs the run report, and writes it to path as JSON unless empty.
+func writeReport(report *runner.Report, path string) error {
+	report.Log()
+	if path == "" {
+		return nil
+	}
+
+	if err := report.WriteJSON(path); err != nil {
+		return err
+	}
+
+	slog.Info("Wrote report", "path", path)
+	return nil
+}
+
+// startProgress follows the progress of the tasks when enabled, redrawn in place on a
+// terminal with the logs scrolling above it. The returned func stops the display.
+func startProgress(tasks []runner.Task, enabled bool) ([]runner.Task, func()) {
+	if !enabled {
+		return tasks, func() {}
+	}
+
+	tracker := progress.New()
+	tasks = tracker.Track(tasks)
+
+	display := progress.NewDisplay(tracker, os.Stderr)
+	restore := func() {}
+	if display.Interactive() {
+		restore = logging.Redirect(display)
+	}
+	display.Start()
+
+	return tasks, func() {
+		display.Stop()
+		restore()
+	}
+}
+
+// newBenchmark measures the tasks of a run when metric tasks record as fast as possible,
+// counting SDK errors along the way. The benchmark is nil otherwise.
+func newBenchmark(cfg *config.Config, tasks []runner.Task) (*runner.Benchmark, []runner.Task) {
+	if !cfg.MaxSpeed() {
+		return nil, tasks
+	}
+
+	slog.Info("Benchmark mode, metric tasks at rate 0 record as fast as possible")
+	bench := runner.NewBenchmark()
+	otel.SetErrorHandler(bench.Handle)
+
+	return bench, bench.Measure(tasks)
+}
+
+// meterProviders hands out the MeterProvider every metric task records through, the one
+// of the running SDK or the one of a backfill.
+type meterProviders interface {
+	MeterProvider(task config.MetricTask) metric.MeterProvider
+}
+
+// newMetricTasks creates a runnable task per configured metric, each one recording
+// through the MeterProvider of its stream. Derived metrics are recorded by their source.
+func newMetricTasks(ctx context.Context, cfg *config.Config, sdk meterProviders, timeline *phases.Timeline) ([]runner.Task, error) {
+	tasks := make([]runner.Task, 0, len(cfg.MetricTasks()))
+	for i, metricCfg := range cfg.MetricTasks() {
+		if metricCfg.DerivedFrom != "" {
+			continue
+		}
+
+		derived, err := newDerivedRecorders(cfg, sdk, metricCfg)
+		if err != nil {
+			return nil, err
+		}
+
+		slog.Info("Queued task",
+			"metric", metricCfg.Name,
+			"generator", metricCfg.Generator,
+			"kind", metricCfg.Kind,
+			"type", metricCfg.Type,
+		)
+
+		task, err := metrictask.New(ctx, metricCfg,
+			metrictask.WithMeterProvider(sdk.MeterProvider(metricCfg)),
+			metrictask.WithTimeline(timeline),
+			metrictask.WithDerived(derived...),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create task %d: %w", i+1, err)
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
+// newDerivedRecorders creates the recorders of the metrics derived from a source, each
+// one feeding the metrics derived from it in turn. Only the derived metrics of the same
+// service, replica and pod as the source are fed by it. Cycles are rejected by Config.Validate.
+func newDerivedRecorders(cfg *config.Config, sdk meterProviders, source config.MetricTask) ([]metrictask.Recorder, error) {
+	var recorders []metrictask.Recorder
+	for _, metricCfg := range cfg.MetricTasks() {
+		if metricCfg.DerivedFrom != source.Name || !sameInstance(metricCfg, source) {
+			continue
+		}
+
+		derived, err := newDerivedRecorders(cfg, sdk, metricCfg)
+		if err != nil {
+			return nil, err
+		}
+
+		recorder, err := metrictask.NewDerived(metricCfg, derived, metrictask.WithMeterProvider(sdk.MeterProvider(metricCfg)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create derived metric %q: %w", metricCfg.Name, err)
+		}
+
+		slog.Info("Derived metric",
+			"metric", metricCfg.Name,
+			"source", source.Name,
+			"expression", metricCfg.Expression,
+		)
+		recorders = append(recorders, recorder)
+	}
+
+	return recorders, nil
+}
+
+// sameInstance tells whether two tasks are exported by the same service instance.
+func sameInstance(a, b config.MetricTask) bool {
+	return a.Service == b.Service && a.Replica == b.Replica && a.Pod == b.Pod
+}
+
+// newTraceTasks creates a runnable task per configured trace, emitting spans through
+// the global TracerProvider.
+func newTraceTasks(ctx context.Context, cfg *config.Config) ([]runner.Task, error) {
+	tasks := make([]runner.Task, 0, len(cfg.TraceTasks()))
+	for i, traceCfg := range cfg.TraceTasks() {
+		slog.Info("Queued task",
+			"trace", traceCfg.Name,
+			"generator", traceCfg.Generator,
+			"depth", traceCfg.Depth,
+			"fan_out", traceCfg.FanOut,
+		)
+
+		task, err := tracetask.New(ctx, traceCfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create trace task %d: %w", i+1, err)
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
+// newLogTasks creates a runnable task per configured log, emitting records through
+// the global LoggerProvider.
+func newLogTasks(ctx context.Context, cfg *config.Config) ([]runner.Task, error) {
+	tasks := make([]runner.Task, 0, len(cfg.LogTasks()))
+	for i, logCfg := range cfg.LogTasks() {
+		slog.Info("Queued task",
+			"log", logCfg.Name,
+			"generator", logCfg.Generator,
+			"severities", logCfg.Severities,
+		)
+
+		task, err := logtask.New(ctx, logCfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create log task %d: %w", i+1, err)
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
+// newScenarioTasks creates a runnable task per configured scenario, correlating spans,
+// logs and the latency histogram of every simulated request.
+func newScenarioTasks(ctx context.Context, cfg *config.Config, sdk *otel.SDK) ([]runner.Task, error) {
+	tasks := make([]runner.Task, 0, len(cfg.ScenarioTasks()))
+	for i, scenarioCfg := range cfg.ScenarioTasks() {
+		slog.Info("Queued task",
+			"scenario", scenarioCfg.Name,
+			"metric", scenarioCfg.Metric,
+			"generator", scenarioCfg.Generator,
+		)
+
+		task, err := scenario.New(ctx, scenarioCfg, scenario.WithMeterProvider(sdk.MeterProvider(scenarioCfg.MetricTask())))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create scenario task %d: %w", i+1, err)
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
 func setupSignalHandler(ctx context.Context) (context.Context, context.CancelFunc) {
 	ctx, cancel := context.WithCancel(ctx)
 
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+type noopMeterProviders struct{}
+
+func (noopMeterProviders) MeterProvider(config.MetricTask) metric.MeterProvider {
+	return noop.NewMeterProvider()
+}
+
+func TestNewDerivedRecorders_Replicas(t *testing.T) {
+	cfg := &config.Config{
+		Metrics: &config.MetricsConfig{Tasks: []config.MetricTask{
+			{Name: "http.server.requests", Kind: "counter", Type: "int64"},
+			{Name: "http.server.errors", Kind: "counter", Type: "int64", DerivedFrom: "http.server.requests", Expression: "value * 0.02"},
+			{Name: "http.server.error.ratio", Kind: "gauge", Type: "float64", DerivedFrom: "http.server.errors", Expression: "value / 10"},
+		}},
+		Replicas: config.ReplicasConfig{Count: 3},
+	}
+	cfg.ExpandReplicas()
+
+	sources := 0
+	for _, task := range cfg.MetricTasks() {
+		if task.DerivedFrom != "" {
+			continue
+		}
+		sources++
+
+		recorders, err := newDerivedRecorders(cfg, noopMeterProviders{}, task)
+		require.NoError(t, err)
+		assert.Len(t, recorders, 1, "replica %d", task.Replica)
+	}
+
+	assert.Equal(t, 3, sources)
+}
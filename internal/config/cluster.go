@@ -0,0 +1,269 @@
+package config
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/neonmei/szgen/internal/consts"
+	"gopkg.in/yaml.v3"
+)
+
+// podNameAlphabet holds the characters Kubernetes uses for generated name suffixes.
+const podNameAlphabet = "bcdfghjklmnpqrstvwxz2456789"
+
+// ClusterConfig simulates a Kubernetes cluster: every namespace runs the same amount of
+// deployments, each deployment the same amount of pods spread over the nodes. Every
+// deployment is exported as a service and every pod through its own resource.
+// Restarts replace a pod during the run, the replacement gets a new name and k8s.pod.uid.
+type ClusterConfig struct {
+	Name          string        `yaml:"name,omitempty"`
+	Nodes         int           `yaml:"nodes"`
+	Namespaces    int           `yaml:"namespaces"`
+	Deployments   int           `yaml:"deployments"`
+	Pods          int           `yaml:"pods"`
+	Restarts      int           `yaml:"restarts,omitempty"`
+	Rate          time.Duration `yaml:"rate,omitempty"`
+	Count         int           `yaml:"count,omitempty"`
+	CPU           string        `yaml:"cpu,omitempty"`
+	Memory        string        `yaml:"memory,omitempty"`
+	Seed          uint64        `yaml:"seed,omitempty"`
+	ShareExporter bool          `yaml:"share_exporter,omitempty"`
+
+	pods []Pod
+}
+
+// Pod is a single incarnation of a pod of the simulated cluster.
+type Pod struct {
+	Namespace  string
+	Deployment string
+	Node       string
+	Name       string
+	UID        string
+	Restarts   int
+}
+
+// UnmarshalYAML samples the cluster with the preset rate and count unless configured.
+func (cc *ClusterConfig) UnmarshalYAML(node *yaml.Node) error {
+	type rawClusterConfig ClusterConfig
+	cluster := rawClusterConfig{Rate: consts.DefaultRate, Count: consts.DefaultPresetCount}
+	if err := node.Decode(&cluster); err != nil {
+		return err
+	}
+
+	*cc = ClusterConfig(cluster)
+
+	return nil
+}
+
+func (cc *ClusterConfig) Validate() error {
+	sizes := []struct {
+		name  string
+		value int
+	}{
+		{"nodes", cc.Nodes},
+		{"namespaces", cc.Namespaces},
+		{"deployments", cc.Deployments},
+		{"pods", cc.Pods},
+	}
+
+	for _, size := range sizes {
+		if size.value < 1 {
+			return fmt.Errorf("cluster: %s must be at least 1, got %d", size.name, size.value)
+		}
+	}
+
+	if cc.Rate <= 0 {
+		return fmt.Errorf("cluster: rate must be positive, got %s", cc.Rate)
+	}
+
+	if cc.Restarts < 0 {
+		return fmt.Errorf("cluster: restarts must be positive, got %d", cc.Restarts)
+	}
+
+	if cc.Count <= cc.Restarts {
+		return fmt.Errorf("cluster: count %d must be greater than restarts %d", cc.Count, cc.Restarts)
+	}
+
+	return nil
+}
+
+// Topology returns every pod incarnation of the cluster, generating it on first use.
+// Names and UIDs are reproducible when a seed is set.
+func (cc *ClusterConfig) Topology() []Pod {
+	if cc.pods != nil {
+		return cc.pods
+	}
+
+	seed := cc.Seed
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+	rnd := rand.New(rand.NewPCG(seed, 0))
+
+	slot := 0
+	for ns := range cc.Namespaces {
+		namespace := fmt.Sprintf("namespace-%d", ns)
+
+		for d := range cc.Deployments {
+			deployment := fmt.Sprintf("app-%d-%d", ns, d)
+			replicaSet := deployment + "-" + randomName(rnd, 10)
+
+			for range cc.Pods {
+				for restart := range cc.Restarts + 1 {
+					cc.pods = append(cc.pods, Pod{
+						Namespace:  namespace,
+						Deployment: deployment,
+						Node:       fmt.Sprintf("node-%d", (slot+restart)%cc.Nodes),
+						Name:       replicaSet + "-" + randomName(rnd, 5),
+						UID:        randomUID(rnd),
+						Restarts:   restart,
+					})
+				}
+				slot++
+			}
+		}
+	}
+
+	return cc.pods
+}
+
+// Services returns a service per deployment, identifying the cluster, namespace and deployment.
+func (cc *ClusterConfig) Services() []ServiceConfig {
+	var services []ServiceConfig
+	for _, pod := range cc.Topology() {
+		if len(services) > 0 && services[len(services)-1].Name == pod.Deployment {
+			continue
+		}
+
+		services = append(services, ServiceConfig{
+			Name: pod.Deployment,
+			Attributes: map[string]any{
+				"k8s.cluster.name":    cc.clusterName(),
+				"k8s.namespace.name":  pod.Namespace,
+				"k8s.deployment.name": pod.Deployment,
+			},
+		})
+	}
+
+	return services
+}
+
+// PodAttributes returns the resource attributes of a pod incarnation.
+func (cc *ClusterConfig) PodAttributes(index int) (map[string]any, error) {
+	pods := cc.Topology()
+	if index < 0 || index >= len(pods) {
+		return nil, fmt.Errorf("cluster: unknown pod %d", index)
+	}
+
+	pod := pods[index]
+	return map[string]any{
+		"k8s.node.name":       pod.Node,
+		"k8s.pod.name":        pod.Name,
+		"k8s.pod.uid":         pod.UID,
+		"k8s.container.name":  pod.Deployment,
+		"service.instance.id": pod.UID,
+	}, nil
+}
+
+// Tasks returns the metric tasks of every pod incarnation. A pod's lifetime is split
+// evenly between its incarnations, each replacement starting once the previous one ends.
+func (cc *ClusterConfig) Tasks() []MetricTask {
+	lifetime := cc.Count / (cc.Restarts + 1)
+
+	var tasks []MetricTask
+	for index, pod := range cc.Topology() {
+		count := lifetime
+		if pod.Restarts == cc.Restarts {
+			count = cc.Count - lifetime*cc.Restarts
+		}
+
+		for _, task := range cc.podTasks(pod, count) {
+			task.Service = pod.Deployment
+			task.Pod = index + 1
+			task.Delay = cc.Rate * time.Duration(lifetime*pod.Restarts)
+			tasks = append(tasks, task)
+		}
+	}
+
+	return tasks
+}
+
+func (cc *ClusterConfig) podTasks(pod Pod, count int) []MetricTask {
+	cpu := cc.CPU
+	if cpu == "" {
+		cpu = consts.DefaultClusterCPU
+	}
+
+	memory := cc.Memory
+	if memory == "" {
+		memory = consts.DefaultClusterMemory
+	}
+
+	return []MetricTask{
+		{
+			Name: "k8s.pod.cpu.usage", Kind: consts.MetricTypeGauge, Type: consts.ValueTypeFloat64,
+			Unit: "{cpu}", Description: "Total CPU usage (sum of all cores per second) consumed by all containers of the Pod.",
+			Rate: Rate(cc.Rate), Count: count, Generator: consts.GeneratorRandom, Value: cpu,
+		},
+		{
+			Name: "k8s.pod.memory.usage", Kind: consts.MetricTypeGauge, Type: consts.ValueTypeInt64,
+			Unit: "By", Description: "Memory usage of the Pod.",
+			Rate: Rate(cc.Rate), Count: count, Generator: consts.GeneratorRandom, Value: memory,
+		},
+		{
+			Name: "k8s.pod.uptime", Kind: consts.MetricTypeGauge, Type: consts.ValueTypeFloat64,
+			Unit: "s", Description: "The time the Pod has been running.",
+			Rate: Rate(cc.Rate), Count: count, Generator: consts.GeneratorStep,
+			Value: "0," + strconv.FormatFloat(cc.Rate.Seconds(), 'f', -1, 64),
+		},
+		{
+			// recorded once, the cumulative sum carries the restarts of the pod it replaced
+			Name: "k8s.container.restart.count", Kind: consts.MetricTypeUpDownCounter, Type: consts.ValueTypeInt64,
+			Unit: "{restart}", Description: "Describes how many times the container has restarted since the last counter reset.",
+			Rate: Rate(cc.Rate), Count: 1, Generator: consts.GeneratorSequence, Value: strconv.Itoa(pod.Restarts),
+		},
+	}
+}
+
+func (cc *ClusterConfig) clusterName() string {
+	if cc.Name == "" {
+		return consts.DefaultClusterName
+	}
+
+	return cc.Name
+}
+
+// ExpandCluster adds the services and metric tasks of the simulated cluster.
+func (c *Config) ExpandCluster() {
+	if c.Cluster == nil {
+		return
+	}
+
+	if c.Metrics == nil {
+		c.Metrics = &MetricsConfig{}
+	}
+
+	c.Services = append(c.Services, c.Cluster.Services()...)
+	c.Metrics.Tasks = append(c.Metrics.Tasks, c.Cluster.Tasks()...)
+}
+
+func randomName(rnd *rand.Rand, length int) string {
+	var sb strings.Builder
+	for range length {
+		sb.WriteByte(podNameAlphabet[rnd.IntN(len(podNameAlphabet))])
+	}
+
+	return sb.String()
+}
+
+// randomUID formats 128 random bits as a version 4 UUID.
+func randomUID(rnd *rand.Rand) string {
+	hi, lo := rnd.Uint64(), rnd.Uint64()
+	hi = hi&^0xf000 | 0x4000
+	lo = lo&^(0xc<<60) | 0x8<<60
+
+	return fmt.Sprintf("%08x-%04x-%04x-%04x-%012x", hi>>32, (hi>>16)&0xffff, hi&0xffff, lo>>48, lo&0xffffffffffff)
+}
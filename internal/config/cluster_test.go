@@ -0,0 +1,146 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/neonmei/szgen/internal/consts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func newTestCluster() *ClusterConfig {
+	return &ClusterConfig{
+		Nodes:       2,
+		Namespaces:  2,
+		Deployments: 2,
+		Pods:        3,
+		Restarts:    1,
+		Rate:        time.Second,
+		Count:       11,
+		Seed:        42,
+	}
+}
+
+func TestClusterConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*ClusterConfig)
+		errMsg string
+	}{
+		{name: "valid", modify: func(*ClusterConfig) {}},
+		{name: "no nodes", modify: func(cc *ClusterConfig) { cc.Nodes = 0 }, errMsg: "nodes must be at least 1"},
+		{name: "no pods", modify: func(cc *ClusterConfig) { cc.Pods = 0 }, errMsg: "pods must be at least 1"},
+		{name: "no rate", modify: func(cc *ClusterConfig) { cc.Rate = 0 }, errMsg: "rate must be positive"},
+		{name: "negative restarts", modify: func(cc *ClusterConfig) { cc.Restarts = -1 }, errMsg: "restarts must be positive"},
+		{name: "more restarts than samples", modify: func(cc *ClusterConfig) { cc.Restarts = 11 }, errMsg: "must be greater than restarts"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cc := newTestCluster()
+			tt.modify(cc)
+
+			err := cc.Validate()
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestClusterConfig_UnmarshalYAML(t *testing.T) {
+	var cc ClusterConfig
+	require.NoError(t, yaml.Unmarshal([]byte("nodes: 1\nnamespaces: 1\ndeployments: 1\npods: 1\n"), &cc))
+	assert.Equal(t, consts.DefaultRate, cc.Rate)
+	assert.Equal(t, consts.DefaultPresetCount, cc.Count)
+}
+
+func TestClusterConfig_Topology(t *testing.T) {
+	cc := newTestCluster()
+	pods := cc.Topology()
+	require.Len(t, pods, 2*2*3*2)
+
+	t.Run("restarts replace the pod", func(t *testing.T) {
+		first, replacement := pods[0], pods[1]
+		assert.Equal(t, 0, first.Restarts)
+		assert.Equal(t, 1, replacement.Restarts)
+		assert.Equal(t, first.Deployment, replacement.Deployment)
+		assert.NotEqual(t, first.Name, replacement.Name)
+		assert.NotEqual(t, first.UID, replacement.UID)
+	})
+
+	t.Run("unique uids", func(t *testing.T) {
+		seen := make(map[string]struct{}, len(pods))
+		for _, pod := range pods {
+			assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, pod.UID)
+			seen[pod.UID] = struct{}{}
+		}
+		assert.Len(t, seen, len(pods))
+	})
+
+	t.Run("seeded topology is reproducible", func(t *testing.T) {
+		assert.Equal(t, pods, newTestCluster().Topology())
+	})
+}
+
+func TestClusterConfig_Services(t *testing.T) {
+	cc := newTestCluster()
+	services := cc.Services()
+	require.Len(t, services, 4)
+	assert.Equal(t, ServiceConfig{
+		Name: "app-0-0",
+		Attributes: map[string]any{
+			"k8s.cluster.name":    consts.DefaultClusterName,
+			"k8s.namespace.name":  "namespace-0",
+			"k8s.deployment.name": "app-0-0",
+		},
+	}, services[0])
+}
+
+func TestClusterConfig_Tasks(t *testing.T) {
+	cc := newTestCluster()
+	tasks := cc.Tasks()
+	require.Len(t, tasks, len(cc.Topology())*4)
+
+	for _, task := range tasks {
+		require.NoError(t, task.Validate(), task.Name)
+	}
+
+	first, replacement := tasks[0], tasks[4]
+	assert.Equal(t, "k8s.pod.cpu.usage", first.Name)
+	assert.Equal(t, 1, first.Pod)
+	assert.Equal(t, "app-0-0", first.Service)
+	assert.Equal(t, 5, first.Count)
+	assert.Zero(t, first.Delay)
+
+	assert.Equal(t, "k8s.pod.cpu.usage", replacement.Name)
+	assert.Equal(t, 2, replacement.Pod)
+	assert.Equal(t, 6, replacement.Count)
+	assert.Equal(t, 5*time.Second, replacement.Delay)
+	assert.Equal(t, "1", tasks[7].Value)
+}
+
+func TestConfig_ExpandCluster(t *testing.T) {
+	cfg := &Config{Cluster: newTestCluster(), OpenTelemetry: NewOTelConfig("test")}
+	cfg.ExpandCluster()
+
+	assert.Len(t, cfg.Services, 4)
+	assert.Len(t, cfg.MetricTasks(), 2*2*3*2*4)
+
+	task := cfg.MetricTasks()[4]
+	otelCfg, err := cfg.StreamOTelConfig(task.Stream(), nil)
+	require.NoError(t, err)
+
+	uid, ok := resourceAttribute(otelCfg, "k8s.pod.uid")
+	require.True(t, ok)
+	assert.Equal(t, cfg.Cluster.Topology()[1].UID, uid)
+
+	namespace, ok := resourceAttribute(otelCfg, "k8s.namespace.name")
+	require.True(t, ok)
+	assert.Equal(t, "namespace-0", namespace)
+}
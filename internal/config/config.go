@@ -11,9 +11,19 @@ import (
 )
 
 type Config struct {
-	Metrics       *MetricsConfig `yaml:"metrics"`
-	OpenTelemetry map[string]any `yaml:"opentelemetry"`
-	Executor      ExecutorConfig `yaml:"executor,omitempty"`
+	Metrics       *MetricsConfig   `yaml:"metrics"`
+	Traces        *TracesConfig    `yaml:"traces,omitempty"`
+	Logs          *LogsConfig      `yaml:"logs,omitempty"`
+	Scenarios     *ScenariosConfig `yaml:"scenarios,omitempty"`
+	Services      []ServiceConfig  `yaml:"services,omitempty"`
+	Replicas      ReplicasConfig   `yaml:"replicas,omitempty"`
+	Cluster       *ClusterConfig   `yaml:"cluster,omitempty"`
+	Phases        []PhaseConfig    `yaml:"phases,omitempty"`
+	OpenTelemetry map[string]any   `yaml:"opentelemetry,omitempty"`
+	Executor      ExecutorConfig   `yaml:"executor,omitempty"`
+
+	// Shard is set from the command line, every process of a sharded run gets its own index.
+	Shard ShardConfig `yaml:"-"`
 }
 
 type Option func(*Config) error
@@ -35,6 +45,9 @@ func NewConfig(options ...Option) (*Config, error) {
 func WithDefaultConfig(serviceVersion string) Option {
 	return func(c *Config) error {
 		c.Metrics = &MetricsConfig{Tasks: []MetricTask{}}
+		c.Traces = &TracesConfig{Tasks: []TraceTask{}}
+		c.Logs = &LogsConfig{Tasks: []LogTask{}}
+		c.Scenarios = &ScenariosConfig{Tasks: []ScenarioTask{}}
 		c.OpenTelemetry = NewOTelConfig(serviceVersion)
 		c.Executor = NewExecutorConfig()
 		return nil
@@ -48,6 +61,27 @@ func WithMetricsConfig(metrics *MetricsConfig) Option {
 	}
 }
 
+func WithTracesConfig(traces *TracesConfig) Option {
+	return func(c *Config) error {
+		c.Traces = traces
+		return nil
+	}
+}
+
+func WithLogsConfig(logs *LogsConfig) Option {
+	return func(c *Config) error {
+		c.Logs = logs
+		return nil
+	}
+}
+
+func WithScenariosConfig(scenarios *ScenariosConfig) Option {
+	return func(c *Config) error {
+		c.Scenarios = scenarios
+		return nil
+	}
+}
+
 func WithOpenTelemetryConfig(otel map[string]any) Option {
 	return func(c *Config) error {
 		c.OpenTelemetry = otel
@@ -55,6 +89,34 @@ func WithOpenTelemetryConfig(otel map[string]any) Option {
 	}
 }
 
+func WithServices(services []ServiceConfig) Option {
+	return func(c *Config) error {
+		c.Services = services
+		return nil
+	}
+}
+
+func WithReplicas(replicas ReplicasConfig) Option {
+	return func(c *Config) error {
+		c.Replicas = replicas
+		return nil
+	}
+}
+
+func WithClusterConfig(cluster *ClusterConfig) Option {
+	return func(c *Config) error {
+		c.Cluster = cluster
+		return nil
+	}
+}
+
+func WithPhases(phases []PhaseConfig) Option {
+	return func(c *Config) error {
+		c.Phases = phases
+		return nil
+	}
+}
+
 func WithExecutorConfig(executor ExecutorConfig) Option {
 	return func(c *Config) error {
 		c.Executor = executor
@@ -112,19 +174,195 @@ func WithSzgenConfigFile(path string) Option {
 	}
 }
 
+// MetricTasks returns the configured metric tasks, if any.
+func (c *Config) MetricTasks() []MetricTask {
+	if c.Metrics == nil {
+		return nil
+	}
+
+	return c.Metrics.Tasks
+}
+
+// MaxSpeed tells whether any metric task records as fast as possible, which turns the
+// run into a benchmark.
+func (c *Config) MaxSpeed() bool {
+	for _, task := range c.MetricTasks() {
+		if task.MaxSpeed() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SetMaxSpeed makes every metric task record as fast as possible, dropping the settings
+// that space its points out.
+func (c *Config) SetMaxSpeed() {
+	if c.Metrics == nil {
+		return
+	}
+
+	for i := range c.Metrics.Tasks {
+		task := &c.Metrics.Tasks[i]
+		if task.DerivedFrom != "" {
+			continue
+		}
+
+		task.Rate = 0
+		task.RateGenerator = nil
+		task.Jitter = ""
+		task.Align = 0
+	}
+}
+
+// metricTaskNames returns the names of the configured metric tasks, including the
+// ones a cluster expands into.
+func (c *Config) metricTaskNames() map[string]struct{} {
+	names := make(map[string]struct{}, len(c.MetricTasks()))
+	for _, task := range c.MetricTasks() {
+		names[task.Name] = struct{}{}
+	}
+
+	if c.Cluster != nil {
+		for _, task := range c.Cluster.podTasks(Pod{}, 1) {
+			names[task.Name] = struct{}{}
+		}
+	}
+
+	return names
+}
+
+// validateTaskSchedules rejects executor schedules of tasks that are not configured and
+// dependencies on them.
+func (c *Config) validateTaskSchedules() error {
+	names := c.metricTaskNames()
+	for _, task := range c.TraceTasks() {
+		names[task.Name] = struct{}{}
+	}
+	for _, task := range c.LogTasks() {
+		names[task.Name] = struct{}{}
+	}
+	for _, task := range c.ScenarioTasks() {
+		names[task.Name] = struct{}{}
+	}
+
+	for i, schedule := range c.Executor.Tasks {
+		if _, ok := names[schedule.Name]; !ok {
+			return fmt.Errorf("executor: task[%d]: unknown task %q", i, schedule.Name)
+		}
+
+		for _, dependency := range schedule.DependsOn {
+			if _, ok := names[dependency]; !ok {
+				return fmt.Errorf("executor: task[%d]: task %q depends on unknown task %q", i, schedule.Name, dependency)
+			}
+		}
+	}
+
+	return nil
+}
+
+// TraceTasks returns the configured trace tasks, if any.
+func (c *Config) TraceTasks() []TraceTask {
+	if c.Traces == nil {
+		return nil
+	}
+
+	return c.Traces.Tasks
+}
+
+// LogTasks returns the configured log tasks, if any.
+func (c *Config) LogTasks() []LogTask {
+	if c.Logs == nil {
+		return nil
+	}
+
+	return c.Logs.Tasks
+}
+
+// ScenarioTasks returns the configured scenario tasks, if any.
+func (c *Config) ScenarioTasks() []ScenarioTask {
+	if c.Scenarios == nil {
+		return nil
+	}
+
+	return c.Scenarios.Tasks
+}
+
 func (c *Config) Validate() error {
-	if len(c.Metrics.Tasks) == 0 {
-		return fmt.Errorf("no metrics defined in configuration")
+	if len(c.MetricTasks()) == 0 && len(c.TraceTasks()) == 0 && len(c.LogTasks()) == 0 && len(c.ScenarioTasks()) == 0 && c.Cluster == nil {
+		return fmt.Errorf("no tasks defined in configuration")
 	}
 
 	if err := c.Executor.Validate(); err != nil {
 		return err
 	}
 
-	for i, metric := range c.Metrics.Tasks {
+	if err := c.validateTaskSchedules(); err != nil {
+		return err
+	}
+
+	if err := validateServices(c.Services); err != nil {
+		return err
+	}
+
+	if err := c.Replicas.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.Shard.Validate(); err != nil {
+		return err
+	}
+
+	if c.Cluster != nil {
+		if err := c.Cluster.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for i, metric := range c.MetricTasks() {
 		if err := metric.Validate(); err != nil {
 			return fmt.Errorf("metric[%d]: %w", i, err)
 		}
+
+		if metric.Service != "" {
+			if _, ok := c.Service(metric.Service); !ok {
+				return fmt.Errorf("metric[%d]: metric %q: unknown service %q", i, metric.Name, metric.Service)
+			}
+		}
+	}
+
+	if err := validateMetricStreams(c.MetricTasks()); err != nil {
+		return err
+	}
+
+	if err := validateStreamTemporality(c.MetricTasks(), c.OpenTelemetry); err != nil {
+		return err
+	}
+
+	if err := c.validateDerived(); err != nil {
+		return err
+	}
+
+	if err := c.validatePhases(); err != nil {
+		return err
+	}
+
+	for i, trace := range c.TraceTasks() {
+		if err := trace.Validate(); err != nil {
+			return fmt.Errorf("trace[%d]: %w", i, err)
+		}
+	}
+
+	for i, log := range c.LogTasks() {
+		if err := log.Validate(); err != nil {
+			return fmt.Errorf("log[%d]: %w", i, err)
+		}
+	}
+
+	for i, scenario := range c.ScenarioTasks() {
+		if err := scenario.Validate(); err != nil {
+			return fmt.Errorf("scenario[%d]: %w", i, err)
+		}
 	}
 
 	if len(c.OpenTelemetry) == 0 {
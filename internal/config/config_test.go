@@ -110,7 +110,7 @@ func TestConfig_Validate(t *testing.T) {
 					Name:      "valid.metric",
 					Kind:      consts.MetricTypeCounter,
 					Type:      consts.ValueTypeInt64,
-					Rate:      time.Second,
+					Rate:      Rate(time.Second),
 					Generator: consts.GeneratorConstant,
 				}},
 			},
@@ -128,7 +128,7 @@ func TestConfig_Validate(t *testing.T) {
 			Metrics: &MetricsConfig{Tasks: []MetricTask{}},
 		}
 		err := cfg.Validate()
-		assert.ErrorContains(t, err, "no metrics defined")
+		assert.ErrorContains(t, err, "no tasks defined")
 	})
 
 	t.Run("invalid executor", func(t *testing.T) {
@@ -151,6 +151,73 @@ func TestConfig_Validate(t *testing.T) {
 		assert.ErrorContains(t, err, "metric[0]")
 	})
 
+	t.Run("conflicting metric streams", func(t *testing.T) {
+		task := MetricTask{
+			Name:      "valid.metric",
+			Kind:      consts.MetricTypeCounter,
+			Type:      consts.ValueTypeInt64,
+			Rate:      Rate(time.Second),
+			Generator: consts.GeneratorConstant,
+		}
+		deltaTask := task
+		deltaTask.Temporality = consts.TemporalityDelta
+		cumulativeTask := task
+		cumulativeTask.Temporality = consts.TemporalityCumulative
+
+		cfg := &Config{
+			Metrics:  &MetricsConfig{Tasks: []MetricTask{deltaTask, cumulativeTask}},
+			Executor: ExecutorConfig{Strategy: consts.ExecutorStrategySerial},
+		}
+		err := cfg.Validate()
+		assert.ErrorContains(t, err, "conflicting temporality")
+	})
+
+	t.Run("traces only", func(t *testing.T) {
+		cfg := &Config{
+			Traces:        &TracesConfig{Tasks: []TraceTask{*NewTraceTask()}},
+			OpenTelemetry: NewOTelConfig("test"),
+			Executor:      ExecutorConfig{Strategy: consts.ExecutorStrategySerial},
+		}
+		assert.NoError(t, cfg.Validate())
+	})
+
+	t.Run("invalid trace task", func(t *testing.T) {
+		cfg := &Config{
+			Traces:   &TracesConfig{Tasks: []TraceTask{*NewTraceTask(WithDepth(0))}},
+			Executor: ExecutorConfig{Strategy: consts.ExecutorStrategySerial},
+		}
+		err := cfg.Validate()
+		assert.ErrorContains(t, err, "trace[0]")
+	})
+
+	t.Run("invalid log task", func(t *testing.T) {
+		cfg := &Config{
+			Logs:     &LogsConfig{Tasks: []LogTask{*NewLogTask(WithLogRate(0))}},
+			Executor: ExecutorConfig{Strategy: consts.ExecutorStrategySerial},
+		}
+		err := cfg.Validate()
+		assert.ErrorContains(t, err, "log[0]")
+	})
+
+	t.Run("unknown service", func(t *testing.T) {
+		cfg := &Config{
+			Metrics: &MetricsConfig{
+				Tasks: []MetricTask{{
+					Name:      "valid.metric",
+					Kind:      consts.MetricTypeCounter,
+					Type:      consts.ValueTypeInt64,
+					Rate:      Rate(time.Second),
+					Generator: consts.GeneratorConstant,
+					Service:   "unknown",
+				}},
+			},
+			Services: []ServiceConfig{{Name: "checkout"}},
+			Executor: ExecutorConfig{Strategy: consts.ExecutorStrategySerial},
+		}
+		err := cfg.Validate()
+		assert.ErrorContains(t, err, "unknown service")
+	})
+
 	t.Run("no otel config", func(t *testing.T) {
 		cfg := &Config{
 			Metrics: &MetricsConfig{
@@ -158,7 +225,7 @@ func TestConfig_Validate(t *testing.T) {
 					Name:      "valid.metric",
 					Kind:      consts.MetricTypeCounter,
 					Type:      consts.ValueTypeInt64,
-					Rate:      time.Second,
+					Rate:      Rate(time.Second),
 					Generator: consts.GeneratorConstant,
 				}},
 			},
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// validateDerived rejects derived metrics whose source is not configured or that depend
+// on themselves, directly or through other derived metrics.
+func (c *Config) validateDerived() error {
+	names := c.metricTaskNames()
+	sources := make(map[string][]string)
+
+	for i, task := range c.MetricTasks() {
+		if task.DerivedFrom == "" {
+			continue
+		}
+
+		if _, ok := names[task.DerivedFrom]; !ok {
+			return fmt.Errorf("metric[%d]: metric %q: unknown source %q", i, task.Name, task.DerivedFrom)
+		}
+
+		if !slices.Contains(sources[task.Name], task.DerivedFrom) {
+			sources[task.Name] = append(sources[task.Name], task.DerivedFrom)
+		}
+	}
+
+	nodes := slices.Sorted(maps.Keys(sources))
+	if cycle := findCycle(nodes, func(name string) []string { return sources[name] }); cycle != nil {
+		return fmt.Errorf("derived metrics cycle: %s", strings.Join(cycle, " -> "))
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricTask_ValidateDerived(t *testing.T) {
+	tests := []struct {
+		name   string
+		task   *MetricTask
+		errMsg string
+	}{
+		{name: "derived", task: NewMetricTask(WithName("errors"), WithRate(0), WithDerivedFrom("requests", "value * 0.02"))},
+		{name: "missing expression", task: NewMetricTask(WithName("errors"), WithDerivedFrom("requests", "")), errMsg: "derived metrics require an expression"},
+		{name: "invalid expression", task: NewMetricTask(WithName("errors"), WithDerivedFrom("requests", "value *")), errMsg: "unexpected end of expression"},
+		{name: "expression without source", task: NewMetricTask(WithName("errors"), WithDerivedFrom("", "value * 2")), errMsg: "expression requires derived_from"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.task.Validate()
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateDerived(t *testing.T) {
+	newConfig := func(tasks ...*MetricTask) *Config {
+		cfg := &Config{Metrics: &MetricsConfig{}}
+		for _, task := range tasks {
+			cfg.Metrics.Tasks = append(cfg.Metrics.Tasks, *task)
+		}
+		return cfg
+	}
+
+	tests := []struct {
+		name   string
+		cfg    *Config
+		errMsg string
+	}{
+		{
+			name: "chain",
+			cfg: newConfig(
+				NewMetricTask(WithName("requests")),
+				NewMetricTask(WithName("errors"), WithDerivedFrom("requests", "value * 0.02")),
+				NewMetricTask(WithName("error.bytes"), WithDerivedFrom("errors", "value * 512")),
+			),
+		},
+		{
+			name: "cluster source",
+			cfg: func() *Config {
+				cfg := newConfig(NewMetricTask(WithName("memory.mib"), WithDerivedFrom("k8s.pod.memory.usage", "value / 1048576")))
+				cfg.Cluster = &ClusterConfig{}
+				return cfg
+			}(),
+		},
+		{
+			name:   "unknown source",
+			cfg:    newConfig(NewMetricTask(WithName("errors"), WithDerivedFrom("requests", "value"))),
+			errMsg: `metric[0]: metric "errors": unknown source "requests"`,
+		},
+		{
+			name:   "self reference",
+			cfg:    newConfig(NewMetricTask(WithName("errors"), WithDerivedFrom("errors", "value"))),
+			errMsg: "derived metrics cycle: errors -> errors",
+		},
+		{
+			name: "cycle",
+			cfg: newConfig(
+				NewMetricTask(WithName("a"), WithDerivedFrom("c", "value")),
+				NewMetricTask(WithName("b"), WithDerivedFrom("a", "value")),
+				NewMetricTask(WithName("c"), WithDerivedFrom("b", "value")),
+			),
+			errMsg: "derived metrics cycle: a -> c -> b -> a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.validateDerived()
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+			}
+		})
+	}
+}
@@ -2,13 +2,46 @@ package config
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/neonmei/szgen/internal/consts"
+	"gopkg.in/yaml.v3"
 )
 
 type ExecutorConfig struct {
 	Strategy string         `yaml:"strategy,omitempty"`
 	Params   map[string]any `yaml:"params,omitempty"`
+	Tasks    []TaskSchedule `yaml:"tasks,omitempty"`
+
+	FailurePolicy FailurePolicy `yaml:"failure_policy,omitempty"`
+
+	// Repeat runs the whole task list again within the same SDK, PauseBetween waits
+	// between iterations.
+	Repeat       Repeat        `yaml:"repeat,omitempty"`
+	PauseBetween time.Duration `yaml:"pause_between,omitempty"`
+
+	// TimeScale speeds up the clock of the run, e.g. 60 runs an hour long schedule in a
+	// minute. 0 and 1 follow the wall clock.
+	TimeScale float64 `yaml:"time_scale,omitempty"`
+}
+
+// Repeat is how many times the task list runs, 0 and 1 run it once. InfiniteRepeat,
+// written "infinite" in configuration files, loops until the run is interrupted.
+type Repeat int
+
+const InfiniteRepeat Repeat = -1
+
+// TaskSchedule tells the dag executor when the tasks with a given name start: once every
+// task they depend on completed and StartAfter elapsed, and not before StartAt since the
+// executor started. Tasks without a schedule start right away.
+type TaskSchedule struct {
+	Name       string        `yaml:"name"`
+	DependsOn  []string      `yaml:"depends_on,omitempty"`
+	StartAfter time.Duration `yaml:"start_after,omitempty"`
+	StartAt    time.Duration `yaml:"start_at,omitempty"`
 }
 
 type ExecutorOption func(*ExecutorConfig)
@@ -29,10 +62,39 @@ func WithExecutorParams(params map[string]any) ExecutorOption {
 	}
 }
 
+func WithTaskSchedules(tasks []TaskSchedule) ExecutorOption {
+	return func(ec *ExecutorConfig) {
+		ec.Tasks = tasks
+	}
+}
+
+func WithRepeat(repeat Repeat, pauseBetween time.Duration) ExecutorOption {
+	return func(ec *ExecutorConfig) {
+		ec.Repeat = repeat
+		ec.PauseBetween = pauseBetween
+	}
+}
+
+func WithTimeScale(scale float64) ExecutorOption {
+	return func(ec *ExecutorConfig) {
+		ec.TimeScale = scale
+	}
+}
+
+func WithFailurePolicy(policy FailurePolicy) ExecutorOption {
+	return func(ec *ExecutorConfig) {
+		if policy.Mode != "" {
+			ec.FailurePolicy = policy
+		}
+	}
+}
+
 func NewExecutorConfig(options ...ExecutorOption) ExecutorConfig {
 	ec := ExecutorConfig{
 		Strategy: consts.DefaultExecutorStrategy,
 		Params:   make(map[string]any),
+
+		FailurePolicy: FailurePolicy{Mode: consts.DefaultFailurePolicy},
 	}
 
 	for _, option := range options {
@@ -47,15 +109,174 @@ func (ec *ExecutorConfig) Validate() error {
 		return err
 	}
 
+	if err := ec.FailurePolicy.Validate(); err != nil {
+		return err
+	}
+
+	if ec.Repeat < InfiniteRepeat {
+		return fmt.Errorf("executor: repeat must be positive or %s, got %d", consts.RepeatInfinite, ec.Repeat)
+	}
+
+	if ec.PauseBetween < 0 {
+		return fmt.Errorf("executor: pause_between must be positive, got %s", ec.PauseBetween)
+	}
+
+	if ec.PauseBetween > 0 && !ec.Repeat.Repeats() {
+		return fmt.Errorf("executor: pause_between requires repeat")
+	}
+
+	if ec.TimeScale < 0 {
+		return fmt.Errorf("executor: time_scale must be positive, got %v", ec.TimeScale)
+	}
+
+	if len(ec.Tasks) > 0 && ec.Strategy != consts.ExecutorStrategyDAG {
+		return fmt.Errorf("executor: task schedules require the %s strategy", consts.ExecutorStrategyDAG)
+	}
+
+	if _, err := ec.MaxConcurrency(); err != nil {
+		return err
+	}
+
+	budget, err := ec.MaxPointsPerSecond()
+	if err != nil {
+		return err
+	}
+
+	if budget < 0 {
+		return fmt.Errorf("executor: %s must be positive, got %v", consts.ParamMaxPointsPerSecond, budget)
+	}
+
+	if ec.Strategy == consts.ExecutorStrategyRamp {
+		if _, err := ec.Ramp(); err != nil {
+			return err
+		}
+	}
+
+	return validateTaskSchedules(ec.Tasks)
+}
+
+// MaxConcurrency reads the max_concurrency param, 0 when unlimited.
+func (ec *ExecutorConfig) MaxConcurrency() (int, error) {
+	return intParam(ec.Params, consts.ParamMaxConcurrency, 0)
+}
+
+// MaxPointsPerSecond reads the max_points_per_second param, 0 when unthrottled.
+func (ec *ExecutorConfig) MaxPointsPerSecond() (float64, error) {
+	return floatParam(ec.Params, consts.ParamMaxPointsPerSecond)
+}
+
+// Schedule looks up the schedule of the tasks with a given name.
+func (ec *ExecutorConfig) Schedule(name string) (TaskSchedule, bool) {
+	idx := slices.IndexFunc(ec.Tasks, func(ts TaskSchedule) bool { return ts.Name == name })
+	if idx < 0 {
+		return TaskSchedule{Name: name}, false
+	}
+
+	return ec.Tasks[idx], true
+}
+
+// ParseRepeat reads a count of iterations or "infinite".
+func ParseRepeat(value string) (Repeat, error) {
+	if value == consts.RepeatInfinite {
+		return InfiniteRepeat, nil
+	}
+
+	count, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("repeat must be a count or %q, got %q", consts.RepeatInfinite, value)
+	}
+
+	return Repeat(count), nil
+}
+
+func (r *Repeat) UnmarshalYAML(node *yaml.Node) error {
+	repeat, err := ParseRepeat(node.Value)
+	if err != nil {
+		return err
+	}
+
+	*r = repeat
+	return nil
+}
+
+// Repeats tells whether the task list runs more than once.
+func (r Repeat) Repeats() bool {
+	return r == InfiniteRepeat || r > 1
+}
+
+func (r Repeat) String() string {
+	if r == InfiniteRepeat {
+		return consts.RepeatInfinite
+	}
+
+	return strconv.Itoa(max(int(r), 1))
+}
+
+func (ts *TaskSchedule) Validate() error {
+	if ts.Name == "" {
+		return fmt.Errorf("empty task name")
+	}
+
+	if ts.StartAfter < 0 {
+		return fmt.Errorf("task %q: start_after must be positive, got %s", ts.Name, ts.StartAfter)
+	}
+
+	if ts.StartAt < 0 {
+		return fmt.Errorf("task %q: start_at must be positive, got %s", ts.Name, ts.StartAt)
+	}
+
+	return nil
+}
+
+// validateTaskSchedules rejects duplicated schedules and dependency cycles. Dependencies
+// may be tasks without a schedule, task names are checked by Config.Validate.
+func validateTaskSchedules(schedules []TaskSchedule) error {
+	names := make(map[string]TaskSchedule, len(schedules))
+	for i, schedule := range schedules {
+		if err := schedule.Validate(); err != nil {
+			return fmt.Errorf("executor: task[%d]: %w", i, err)
+		}
+
+		if _, ok := names[schedule.Name]; ok {
+			return fmt.Errorf("executor: task[%d]: duplicate task %q", i, schedule.Name)
+		}
+		names[schedule.Name] = schedule
+	}
+
+	nodes := make([]string, len(schedules))
+	for i, schedule := range schedules {
+		nodes[i] = schedule.Name
+	}
+
+	if cycle := findCycle(nodes, func(name string) []string { return names[name].DependsOn }); cycle != nil {
+		return fmt.Errorf("executor: dependency cycle: %s", strings.Join(cycle, " -> "))
+	}
+
 	return nil
 }
 
 func ValidateExecutorStrategy(strategy string) error {
 	switch strategy {
-	case consts.ExecutorStrategySerial, consts.ExecutorStrategyConcurrent:
+	case consts.ExecutorStrategySerial, consts.ExecutorStrategyConcurrent, consts.ExecutorStrategyDAG, consts.ExecutorStrategyRamp:
 		return nil
 	default:
-		return fmt.Errorf("invalid executor strategy '%s', must be one of: %s, %s",
-			strategy, consts.ExecutorStrategySerial, consts.ExecutorStrategyConcurrent)
+		return fmt.Errorf("invalid executor strategy '%s', must be one of: %s, %s, %s, %s",
+			strategy, consts.ExecutorStrategySerial, consts.ExecutorStrategyConcurrent, consts.ExecutorStrategyDAG, consts.ExecutorStrategyRamp)
+	}
+}
+
+func floatParam(params map[string]any, key string) (float64, error) {
+	val, ok := params[key]
+	if !ok {
+		return 0, nil
+	}
+
+	switch v := val.(type) {
+	case int:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	default:
+		return 0, fmt.Errorf("executor: %s must be a number, got %v", key, val)
 	}
 }
@@ -2,9 +2,12 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/neonmei/szgen/internal/consts"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestNewExecutorConfig(t *testing.T) {
@@ -12,6 +15,7 @@ func TestNewExecutorConfig(t *testing.T) {
 		cfg := NewExecutorConfig()
 		assert.Equal(t, consts.DefaultExecutorStrategy, cfg.Strategy)
 		assert.Empty(t, cfg.Params)
+		assert.True(t, cfg.FailurePolicy.FailFast())
 	})
 
 	t.Run("with options", func(t *testing.T) {
@@ -19,9 +23,11 @@ func TestNewExecutorConfig(t *testing.T) {
 		cfg := NewExecutorConfig(
 			WithExecutorStrategy(consts.ExecutorStrategyConcurrent),
 			WithExecutorParams(params),
+			WithFailurePolicy(FailurePolicy{Mode: consts.FailurePolicyContinue}),
 		)
 		assert.Equal(t, consts.ExecutorStrategyConcurrent, cfg.Strategy)
 		assert.Equal(t, params, cfg.Params)
+		assert.Equal(t, consts.FailurePolicyContinue, cfg.FailurePolicy.Mode)
 	})
 
 	t.Run("empty options ignored", func(t *testing.T) {
@@ -55,6 +61,116 @@ func TestExecutorConfig_Validate(t *testing.T) {
 			cfg:     ExecutorConfig{Strategy: "invalid"},
 			wantErr: true,
 		},
+		{
+			name: "valid dag",
+			cfg: ExecutorConfig{Strategy: consts.ExecutorStrategyDAG, Tasks: []TaskSchedule{
+				{Name: "warmup"},
+				{Name: "load", DependsOn: []string{"warmup"}, StartAfter: time.Second},
+				{Name: "spike", DependsOn: []string{"warmup", "load"}, StartAt: time.Minute},
+			}},
+			wantErr: false,
+		},
+		{
+			name:    "schedules without dag",
+			cfg:     ExecutorConfig{Strategy: consts.ExecutorStrategyConcurrent, Tasks: []TaskSchedule{{Name: "warmup"}}},
+			wantErr: true,
+		},
+		{
+			name:    "dependency without schedule",
+			cfg:     ExecutorConfig{Strategy: consts.ExecutorStrategyDAG, Tasks: []TaskSchedule{{Name: "load", DependsOn: []string{"warmup"}}}},
+			wantErr: false,
+		},
+		{
+			name:    "duplicate schedule",
+			cfg:     ExecutorConfig{Strategy: consts.ExecutorStrategyDAG, Tasks: []TaskSchedule{{Name: "load"}, {Name: "load"}}},
+			wantErr: true,
+		},
+		{
+			name:    "negative delay",
+			cfg:     ExecutorConfig{Strategy: consts.ExecutorStrategyDAG, Tasks: []TaskSchedule{{Name: "load", StartAfter: -time.Second}}},
+			wantErr: true,
+		},
+		{
+			name:    "points budget",
+			cfg:     ExecutorConfig{Strategy: consts.ExecutorStrategyConcurrent, Params: map[string]any{consts.ParamMaxPointsPerSecond: 2500.5}},
+			wantErr: false,
+		},
+		{
+			name:    "negative points budget",
+			cfg:     ExecutorConfig{Strategy: consts.ExecutorStrategySerial, Params: map[string]any{consts.ParamMaxPointsPerSecond: -1}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid points budget",
+			cfg:     ExecutorConfig{Strategy: consts.ExecutorStrategySerial, Params: map[string]any{consts.ParamMaxPointsPerSecond: "fast"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid max concurrency",
+			cfg:     ExecutorConfig{Strategy: consts.ExecutorStrategyConcurrent, Params: map[string]any{consts.ParamMaxConcurrency: "many"}},
+			wantErr: true,
+		},
+		{
+			name:    "repeat",
+			cfg:     ExecutorConfig{Strategy: consts.ExecutorStrategySerial, Repeat: InfiniteRepeat, PauseBetween: time.Second},
+			wantErr: false,
+		},
+		{
+			name:    "invalid repeat",
+			cfg:     ExecutorConfig{Strategy: consts.ExecutorStrategySerial, Repeat: -2},
+			wantErr: true,
+		},
+		{
+			name:    "pause without repeat",
+			cfg:     ExecutorConfig{Strategy: consts.ExecutorStrategySerial, Repeat: 1, PauseBetween: time.Second},
+			wantErr: true,
+		},
+		{
+			name:    "time scale",
+			cfg:     ExecutorConfig{Strategy: consts.ExecutorStrategySerial, TimeScale: 60},
+			wantErr: false,
+		},
+		{
+			name:    "negative time scale",
+			cfg:     ExecutorConfig{Strategy: consts.ExecutorStrategySerial, TimeScale: -1},
+			wantErr: true,
+		},
+		{
+			name: "valid ramp",
+			cfg: ExecutorConfig{Strategy: consts.ExecutorStrategyRamp, Params: map[string]any{
+				consts.ParamRampStart: 1, consts.ParamRampEnd: 10, consts.ParamRampUp: "1m", consts.ParamRampHold: "5m", consts.ParamRampDown: 30,
+			}},
+			wantErr: false,
+		},
+		{
+			name:    "ramp without stages",
+			cfg:     ExecutorConfig{Strategy: consts.ExecutorStrategyRamp, Params: map[string]any{consts.ParamRampEnd: 10}},
+			wantErr: true,
+		},
+		{
+			name:    "ramp start after end",
+			cfg:     ExecutorConfig{Strategy: consts.ExecutorStrategyRamp, Params: map[string]any{consts.ParamRampStart: 5, consts.ParamRampEnd: 2, consts.ParamRampHold: "1m"}},
+			wantErr: true,
+		},
+		{
+			name:    "ramp invalid duration",
+			cfg:     ExecutorConfig{Strategy: consts.ExecutorStrategyRamp, Params: map[string]any{consts.ParamRampUp: "soon"}},
+			wantErr: true,
+		},
+		{
+			name:    "ramp invalid count",
+			cfg:     ExecutorConfig{Strategy: consts.ExecutorStrategyRamp, Params: map[string]any{consts.ParamRampEnd: "ten", consts.ParamRampHold: "1m"}},
+			wantErr: true,
+		},
+		{
+			name: "cycle",
+			cfg: ExecutorConfig{Strategy: consts.ExecutorStrategyDAG, Tasks: []TaskSchedule{
+				{Name: "a", DependsOn: []string{"b"}},
+				{Name: "b", DependsOn: []string{"c"}},
+				{Name: "c", DependsOn: []string{"a"}},
+			}},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -68,3 +184,62 @@ func TestExecutorConfig_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateTaskSchedules_Cycle(t *testing.T) {
+	err := validateTaskSchedules([]TaskSchedule{
+		{Name: "a", DependsOn: []string{"b"}},
+		{Name: "b", DependsOn: []string{"a"}},
+	})
+	assert.EqualError(t, err, "executor: dependency cycle: a -> b -> a")
+}
+
+func TestConfig_ValidateTaskSchedules(t *testing.T) {
+	cfg := &Config{
+		Metrics: &MetricsConfig{Tasks: []MetricTask{{Name: "requests"}}},
+		Traces:  &TracesConfig{Tasks: []TraceTask{{Name: "checkout"}}},
+		Executor: ExecutorConfig{Strategy: consts.ExecutorStrategyDAG, Tasks: []TaskSchedule{
+			{Name: "requests"},
+			{Name: "checkout", DependsOn: []string{"requests"}},
+		}},
+	}
+	assert.NoError(t, cfg.validateTaskSchedules())
+
+	cfg.Executor.Tasks = []TaskSchedule{{Name: "checkout", DependsOn: []string{"requests"}}}
+	assert.NoError(t, cfg.validateTaskSchedules(), "dependencies need no schedule")
+
+	cfg.Executor.Tasks = append(cfg.Executor.Tasks, TaskSchedule{Name: "missing"})
+	assert.EqualError(t, cfg.validateTaskSchedules(), `executor: task[1]: unknown task "missing"`)
+
+	cfg.Executor.Tasks = []TaskSchedule{{Name: "checkout", DependsOn: []string{"missing"}}}
+	assert.EqualError(t, cfg.validateTaskSchedules(), `executor: task[0]: task "checkout" depends on unknown task "missing"`)
+}
+
+func TestRepeat(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Repeat
+		repeats  bool
+		wantErr  bool
+	}{
+		{input: "1", expected: 1},
+		{input: "5", expected: 5, repeats: true},
+		{input: consts.RepeatInfinite, expected: InfiniteRepeat, repeats: true},
+		{input: "forever", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			var ec ExecutorConfig
+			err := yaml.Unmarshal([]byte("repeat: "+tt.input), &ec)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ec.Repeat)
+			assert.Equal(t, tt.repeats, ec.Repeat.Repeats())
+			assert.Equal(t, tt.input, ec.Repeat.String())
+		})
+	}
+}
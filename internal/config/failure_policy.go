@@ -0,0 +1,87 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/neonmei/szgen/internal/consts"
+	"gopkg.in/yaml.v3"
+)
+
+// FailurePolicy decides what happens to a run when a task fails: fail_fast cancels every
+// other task, continue lets them run and reports the failures at the end, and retry
+// runs the task again up to MaxAttempts times, doubling Backoff between attempts up to
+// consts.MaxRetryBackoff, before reporting it like continue does.
+type FailurePolicy struct {
+	Mode        string        `yaml:"mode,omitempty"`
+	MaxAttempts int           `yaml:"max_attempts,omitempty"`
+	Backoff     time.Duration `yaml:"backoff,omitempty"`
+}
+
+// UnmarshalYAML also accepts the mode alone, e.g. failure_policy: continue.
+func (fp *FailurePolicy) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		fp.Mode = node.Value
+		return nil
+	}
+
+	type rawFailurePolicy FailurePolicy
+	return node.Decode((*rawFailurePolicy)(fp))
+}
+
+func (fp FailurePolicy) Validate() error {
+	switch fp.Mode {
+	case "", consts.FailurePolicyFailFast, consts.FailurePolicyContinue, consts.FailurePolicyRetry:
+	default:
+		return fmt.Errorf("executor: invalid failure policy %q, must be one of: %s, %s, %s",
+			fp.Mode, consts.FailurePolicyFailFast, consts.FailurePolicyContinue, consts.FailurePolicyRetry)
+	}
+
+	if fp.MaxAttempts < 0 {
+		return fmt.Errorf("executor: failure policy max_attempts must be positive, got %d", fp.MaxAttempts)
+	}
+
+	if fp.Backoff < 0 {
+		return fmt.Errorf("executor: failure policy backoff must be positive, got %s", fp.Backoff)
+	}
+
+	if fp.Mode != consts.FailurePolicyRetry && (fp.MaxAttempts > 0 || fp.Backoff > 0) {
+		return fmt.Errorf("executor: max_attempts and backoff require the %s failure policy", consts.FailurePolicyRetry)
+	}
+
+	return nil
+}
+
+// FailFast tells whether a failed task aborts the run, the default.
+func (fp FailurePolicy) FailFast() bool {
+	return fp.Mode == "" || fp.Mode == consts.FailurePolicyFailFast
+}
+
+// Attempts is the amount of times a task runs before it's considered failed.
+func (fp FailurePolicy) Attempts() int {
+	if fp.Mode != consts.FailurePolicyRetry {
+		return 1
+	}
+
+	if fp.MaxAttempts == 0 {
+		return consts.DefaultRetryAttempts
+	}
+
+	return fp.MaxAttempts
+}
+
+// RetryBackoff is the wait before the given retry, doubling from one attempt to the next
+// up to consts.MaxRetryBackoff.
+func (fp FailurePolicy) RetryBackoff(retry int) time.Duration {
+	backoff := fp.Backoff
+	if backoff == 0 {
+		backoff = consts.DefaultRetryBackoff
+	}
+
+	// doubling stops once the cap is reached, so the backoff never overflows
+	for ; retry > 1 && backoff < consts.MaxRetryBackoff; retry-- {
+		backoff *= 2
+	}
+
+	return min(backoff, consts.MaxRetryBackoff)
+}
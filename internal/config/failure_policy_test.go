@@ -0,0 +1,83 @@
+package config
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/neonmei/szgen/internal/consts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestFailurePolicy_UnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected FailurePolicy
+	}{
+		{
+			name:     "mode only",
+			input:    "failure_policy: continue",
+			expected: FailurePolicy{Mode: consts.FailurePolicyContinue},
+		},
+		{
+			name:     "retry",
+			input:    "failure_policy: {mode: retry, max_attempts: 5, backoff: 2s}",
+			expected: FailurePolicy{Mode: consts.FailurePolicyRetry, MaxAttempts: 5, Backoff: 2 * time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ec ExecutorConfig
+			require.NoError(t, yaml.Unmarshal([]byte(tt.input), &ec))
+			assert.Equal(t, tt.expected, ec.FailurePolicy)
+			assert.NoError(t, ec.FailurePolicy.Validate())
+		})
+	}
+}
+
+func TestFailurePolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  FailurePolicy
+		wantErr string
+	}{
+		{name: "default", policy: FailurePolicy{}},
+		{name: "fail fast", policy: FailurePolicy{Mode: consts.FailurePolicyFailFast}},
+		{name: "invalid mode", policy: FailurePolicy{Mode: "ignore"}, wantErr: `invalid failure policy "ignore"`},
+		{name: "negative attempts", policy: FailurePolicy{Mode: consts.FailurePolicyRetry, MaxAttempts: -1}, wantErr: "max_attempts must be positive"},
+		{name: "negative backoff", policy: FailurePolicy{Mode: consts.FailurePolicyRetry, Backoff: -time.Second}, wantErr: "backoff must be positive"},
+		{name: "attempts without retry", policy: FailurePolicy{Mode: consts.FailurePolicyContinue, MaxAttempts: 3}, wantErr: "require the retry failure policy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFailurePolicy_Retries(t *testing.T) {
+	assert.Equal(t, 1, FailurePolicy{}.Attempts())
+	assert.Equal(t, 1, FailurePolicy{Mode: consts.FailurePolicyContinue}.Attempts())
+	assert.Equal(t, consts.DefaultRetryAttempts, FailurePolicy{Mode: consts.FailurePolicyRetry}.Attempts())
+
+	policy := FailurePolicy{Mode: consts.FailurePolicyRetry, MaxAttempts: 4, Backoff: 500 * time.Millisecond}
+	assert.Equal(t, 4, policy.Attempts())
+	assert.Equal(t, 500*time.Millisecond, policy.RetryBackoff(1))
+	assert.Equal(t, 2*time.Second, policy.RetryBackoff(3))
+	assert.Equal(t, consts.DefaultRetryBackoff, FailurePolicy{Mode: consts.FailurePolicyRetry}.RetryBackoff(1))
+
+	// a large retry index is capped rather than overflowing
+	assert.Equal(t, consts.MaxRetryBackoff, policy.RetryBackoff(100))
+	assert.Equal(t, consts.MaxRetryBackoff, policy.RetryBackoff(math.MaxInt))
+	assert.Equal(t, consts.MaxRetryBackoff, FailurePolicy{Mode: consts.FailurePolicyRetry, Backoff: time.Hour}.RetryBackoff(1))
+}
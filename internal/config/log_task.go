@@ -0,0 +1,150 @@
+package config
+
+import (
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/neonmei/szgen/internal/consts"
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	LogTaskOption func(*LogTask)
+
+	// LogTask emits log records every Rate for Count ticks. The amount of records per tick
+	// is drawn from the value generator, every record picks a severity from the Severities
+	// weights and renders Body as a text/template with the task Name, record Index, Tick
+	// and Severity. A non-zero Seed makes volumes and severities reproducible.
+	LogTask struct {
+		Name       string             `yaml:"name"`
+		Rate       time.Duration      `yaml:"rate,omitempty"`
+		Count      int                `yaml:"count,omitempty"`
+		Body       string             `yaml:"body,omitempty"`
+		Severities map[string]float64 `yaml:"severities,omitempty"`
+		Value      string             `yaml:"value,omitempty"`
+		Generator  string             `yaml:"generator,omitempty"`
+		Attributes map[string]any     `yaml:"attributes,omitempty"`
+		Seed       uint64             `yaml:"seed,omitempty"`
+	}
+)
+
+func NewLogTask(options ...LogTaskOption) *LogTask {
+	lt := &LogTask{
+		Name:      consts.DefaultLogName,
+		Rate:      consts.DefaultRate,
+		Count:     consts.DefaultCount,
+		Body:      consts.DefaultLogBody,
+		Value:     consts.DefaultValue,
+		Generator: consts.DefaultGenerator,
+	}
+
+	for _, option := range options {
+		option(lt)
+	}
+
+	return lt
+}
+
+func (lt *LogTask) Validate() error {
+	if lt.Name == "" {
+		return fmt.Errorf("empty log name")
+	}
+
+	if err := ValidateGenerator(lt.Generator); err != nil {
+		return fmt.Errorf("log %q: %w", lt.Name, err)
+	}
+
+	if lt.Rate == 0 {
+		return fmt.Errorf("log %q: empty rate", lt.Name)
+	}
+
+	if _, err := template.New(lt.Name).Parse(lt.Body); err != nil {
+		return fmt.Errorf("log %q: invalid body template: %w", lt.Name, err)
+	}
+
+	var total float64
+	for severity, weight := range lt.Severities {
+		if err := ValidateSeverity(severity); err != nil {
+			return fmt.Errorf("log %q: %w", lt.Name, err)
+		}
+
+		if weight < 0 {
+			return fmt.Errorf("log %q: severity %q weight must be positive, got %v", lt.Name, severity, weight)
+		}
+		total += weight
+	}
+
+	if len(lt.Severities) > 0 && total == 0 {
+		return fmt.Errorf("log %q: severity weights cannot all be zero", lt.Name)
+	}
+
+	return nil
+}
+
+func (lt *LogTask) UnmarshalYAML(node *yaml.Node) error {
+	defaultTask := NewLogTask()
+
+	type rawLogTask LogTask
+	if err := node.Decode((*rawLogTask)(defaultTask)); err != nil {
+		return err
+	}
+
+	*lt = *defaultTask
+
+	return nil
+}
+
+func WithLogName(name string) LogTaskOption {
+	return func(lt *LogTask) {
+		lt.Name = name
+	}
+}
+
+func WithLogRate(rate time.Duration) LogTaskOption {
+	return func(lt *LogTask) {
+		lt.Rate = rate
+	}
+}
+
+func WithLogCount(count int) LogTaskOption {
+	return func(lt *LogTask) {
+		lt.Count = count
+	}
+}
+
+func WithBody(body string) LogTaskOption {
+	return func(lt *LogTask) {
+		lt.Body = body
+	}
+}
+
+func WithSeverities(severities map[string]float64) LogTaskOption {
+	return func(lt *LogTask) {
+		lt.Severities = severities
+	}
+}
+
+func WithLogValue(value string) LogTaskOption {
+	return func(lt *LogTask) {
+		lt.Value = value
+	}
+}
+
+func WithLogGenerator(generator string) LogTaskOption {
+	return func(lt *LogTask) {
+		lt.Generator = generator
+	}
+}
+
+func WithLogAttributes(attrs map[string]any) LogTaskOption {
+	return func(lt *LogTask) {
+		lt.Attributes = attrs
+	}
+}
+
+func WithLogSeed(seed uint64) LogTaskOption {
+	return func(lt *LogTask) {
+		lt.Seed = seed
+	}
+}
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/neonmei/szgen/internal/consts"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestNewLogTask(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		lt := NewLogTask()
+		assert.Equal(t, consts.DefaultLogName, lt.Name)
+		assert.Equal(t, consts.DefaultRate, lt.Rate)
+		assert.Equal(t, consts.DefaultCount, lt.Count)
+		assert.Equal(t, consts.DefaultLogBody, lt.Body)
+		assert.Equal(t, consts.DefaultValue, lt.Value)
+		assert.Equal(t, consts.DefaultGenerator, lt.Generator)
+		assert.Empty(t, lt.Severities)
+	})
+
+	t.Run("with options", func(t *testing.T) {
+		lt := NewLogTask(
+			WithLogName("audit"),
+			WithLogRate(time.Second),
+			WithLogCount(5),
+			WithBody("user {{ .Index }} logged in"),
+			WithSeverities(map[string]float64{consts.SeverityInfo: 0.9, consts.SeverityWarn: 0.1}),
+			WithLogValue("10"),
+			WithLogGenerator(consts.GeneratorRandom),
+			WithLogAttributes(map[string]any{"key": "val"}),
+		)
+
+		assert.Equal(t, "audit", lt.Name)
+		assert.Equal(t, time.Second, lt.Rate)
+		assert.Equal(t, 5, lt.Count)
+		assert.Equal(t, "user {{ .Index }} logged in", lt.Body)
+		assert.Equal(t, map[string]float64{consts.SeverityInfo: 0.9, consts.SeverityWarn: 0.1}, lt.Severities)
+		assert.Equal(t, "10", lt.Value)
+		assert.Equal(t, consts.GeneratorRandom, lt.Generator)
+		assert.Equal(t, map[string]any{"key": "val"}, lt.Attributes)
+	})
+}
+
+func TestLogTask_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		task    *LogTask
+		wantErr string
+	}{
+		{
+			name: "valid task",
+			task: NewLogTask(WithSeverities(map[string]float64{consts.SeverityError: 1, consts.SeverityDebug: 0})),
+		},
+		{
+			name:    "empty name",
+			task:    NewLogTask(WithLogName("")),
+			wantErr: "empty log name",
+		},
+		{
+			name:    "invalid generator",
+			task:    NewLogTask(WithLogGenerator("unknown")),
+			wantErr: "invalid generator",
+		},
+		{
+			name:    "empty rate",
+			task:    NewLogTask(WithLogRate(0)),
+			wantErr: "empty rate",
+		},
+		{
+			name:    "invalid body template",
+			task:    NewLogTask(WithBody("{{ .Index")),
+			wantErr: "invalid body template",
+		},
+		{
+			name:    "invalid severity",
+			task:    NewLogTask(WithSeverities(map[string]float64{"critical": 1})),
+			wantErr: "invalid severity",
+		},
+		{
+			name:    "negative weight",
+			task:    NewLogTask(WithSeverities(map[string]float64{consts.SeverityInfo: -1})),
+			wantErr: "must be positive",
+		},
+		{
+			name:    "all weights zero",
+			task:    NewLogTask(WithSeverities(map[string]float64{consts.SeverityInfo: 0})),
+			wantErr: "cannot all be zero",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.task.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestLogTask_UnmarshalYAML(t *testing.T) {
+	data := `
+name: audit
+body: "user {{ .Index }} logged in"
+severities:
+  info: 0.9
+  warn: 0.1
+`
+	var lt LogTask
+	err := yaml.Unmarshal([]byte(data), &lt)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "audit", lt.Name)
+	assert.Equal(t, "user {{ .Index }} logged in", lt.Body)
+	assert.Equal(t, map[string]float64{consts.SeverityInfo: 0.9, consts.SeverityWarn: 0.1}, lt.Severities)
+	assert.Equal(t, consts.DefaultRate, lt.Rate)
+	assert.Equal(t, consts.DefaultValue, lt.Value)
+}
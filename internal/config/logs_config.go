@@ -0,0 +1,5 @@
+package config
+
+type LogsConfig struct {
+	Tasks []LogTask `yaml:"tasks"`
+}
@@ -2,9 +2,13 @@ package config
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/neonmei/szgen/internal/consts"
+	"github.com/neonmei/szgen/internal/expression"
 	"gopkg.in/yaml.v3"
 )
 
@@ -14,13 +18,52 @@ type (
 		Name        string         `yaml:"name"`
 		Kind        string         `yaml:"kind"`
 		Type        string         `yaml:"type,omitempty"`
-		Rate        time.Duration  `yaml:"rate,omitempty"`
+		Rate        Rate           `yaml:"rate,omitempty"`
 		Count       int            `yaml:"count,omitempty"`
 		Value       string         `yaml:"value,omitempty"`
 		Attributes  map[string]any `yaml:"attributes,omitempty"`
 		Generator   string         `yaml:"generator,omitempty"`
 		Description string         `yaml:"description,omitempty"`
 		Unit        string         `yaml:"unit,omitempty"`
+		Service     string         `yaml:"service,omitempty"`
+		Replica     int            `yaml:"-"`
+		Pod         int            `yaml:"-"`
+		Delay       time.Duration  `yaml:"-"`
+		Temporality string         `yaml:"temporality,omitempty"`
+		Aggregation string         `yaml:"aggregation,omitempty"`
+		Buckets     []float64      `yaml:"buckets,omitempty"`
+		DerivedFrom string         `yaml:"derived_from,omitempty"`
+		Expression  string         `yaml:"expression,omitempty"`
+		Latency     *LatencyConfig `yaml:"latency,omitempty"`
+		Counter     string         `yaml:"counter,omitempty"`
+
+		// Level makes the values of an updowncounter its level rather than changes: every
+		// point adds the change since the previous level, so the sum follows the generator.
+		Level bool `yaml:"level,omitempty"`
+
+		// EmitImmediately records the first point right away, Jitter delays every point
+		// by up to a duration or a percentage of the rate and Align starts the task on a
+		// wall-clock boundary.
+		EmitImmediately bool          `yaml:"emit_immediately,omitempty"`
+		Jitter          string        `yaml:"jitter,omitempty"`
+		Align           time.Duration `yaml:"align,omitempty"`
+
+		RateGenerator *RateGeneratorConfig `yaml:"rate_generator,omitempty"`
+	}
+
+	// LatencyConfig turns a histogram task into a request-driven one: the task generator
+	// yields the requests of every tick, each request recording one observation drawn
+	// from the latency generator.
+	LatencyConfig struct {
+		Generator string `yaml:"generator,omitempty"`
+		Value     string `yaml:"value"`
+	}
+
+	// RateGeneratorConfig varies the time between the points of a task, its generator
+	// yields the seconds until the next point, e.g. exponential for Poisson timing.
+	RateGeneratorConfig struct {
+		Generator string `yaml:"generator,omitempty"`
+		Value     string `yaml:"value"`
 	}
 )
 
@@ -29,7 +72,7 @@ func NewMetricTask(options ...MetricTaskOption) *MetricTask {
 		Name:      consts.DefaultMetricName,
 		Kind:      consts.DefaultMetricKind,
 		Type:      consts.DefaultValueType,
-		Rate:      consts.DefaultRate,
+		Rate:      Rate(consts.DefaultRate),
 		Count:     consts.DefaultCount,
 		Value:     consts.DefaultValue,
 		Generator: consts.DefaultGenerator,
@@ -59,13 +102,182 @@ func (mc *MetricTask) Validate() error {
 		return fmt.Errorf("metric %q: %w", mc.Name, err)
 	}
 
-	if mc.Rate == 0 {
-		return fmt.Errorf("metric %q: empty rate", mc.Name)
+	if mc.DerivedFrom != "" {
+		if mc.Expression == "" {
+			return fmt.Errorf("metric %q: derived metrics require an expression", mc.Name)
+		}
+
+		if _, err := expression.Parse(mc.Expression); err != nil {
+			return fmt.Errorf("metric %q: %w", mc.Name, err)
+		}
+	} else if mc.Expression != "" {
+		return fmt.Errorf("metric %q: expression requires derived_from", mc.Name)
+	}
+
+	if err := mc.validateLatency(); err != nil {
+		return err
+	}
+
+	if err := mc.validateSchedule(); err != nil {
+		return err
+	}
+
+	if mc.Level && (mc.Kind != consts.MetricTypeUpDownCounter || mc.DerivedFrom != "") {
+		return fmt.Errorf("metric %q: level only applies to %s metrics with a generator", mc.Name, consts.MetricTypeUpDownCounter)
+	}
+
+	if mc.Rate < 0 {
+		return fmt.Errorf("metric %q: rate must be positive, got %s", mc.Name, mc.Rate)
+	}
+
+	if mc.Temporality != "" {
+		if err := ValidateTemporality(mc.Temporality); err != nil {
+			return fmt.Errorf("metric %q: %w", mc.Name, err)
+		}
+	}
+
+	if mc.Aggregation != "" {
+		if err := ValidateAggregation(mc.Aggregation); err != nil {
+			return fmt.Errorf("metric %q: %w", mc.Name, err)
+		}
+
+		if mc.Kind != consts.MetricTypeHistogram {
+			return fmt.Errorf("metric %q: aggregation only applies to %s metrics", mc.Name, consts.MetricTypeHistogram)
+		}
+	}
+
+	if len(mc.Buckets) > 0 {
+		if mc.Aggregation != consts.AggregationExplicitBucketHistogram {
+			return fmt.Errorf("metric %q: buckets require %s aggregation", mc.Name, consts.AggregationExplicitBucketHistogram)
+		}
+
+		if !slices.IsSorted(mc.Buckets) {
+			return fmt.Errorf("metric %q: buckets must be sorted in ascending order", mc.Name)
+		}
+	}
+
+	return nil
+}
+
+func (mc *MetricTask) validateLatency() error {
+	if mc.Latency == nil {
+		if mc.Counter != "" {
+			return fmt.Errorf("metric %q: counter requires latency", mc.Name)
+		}
+		return nil
+	}
+
+	if mc.Kind != consts.MetricTypeHistogram {
+		return fmt.Errorf("metric %q: latency only applies to %s metrics", mc.Name, consts.MetricTypeHistogram)
+	}
+
+	if mc.DerivedFrom != "" {
+		return fmt.Errorf("metric %q: derived metrics can't be request-driven", mc.Name)
+	}
+
+	if mc.Latency.Value == "" {
+		return fmt.Errorf("metric %q: empty latency value", mc.Name)
+	}
+
+	if err := ValidateGenerator(mc.Latency.Generator); err != nil {
+		return fmt.Errorf("metric %q: latency: %w", mc.Name, err)
+	}
+
+	if mc.Counter != "" {
+		if err := ValidateMetricName(mc.Counter); err != nil {
+			return fmt.Errorf("metric %q: counter: %w", mc.Name, err)
+		}
+
+		if mc.Counter == mc.Name {
+			return fmt.Errorf("metric %q: counter must be named differently than the histogram", mc.Name)
+		}
+	}
+
+	return nil
+}
+
+func (mc *MetricTask) validateSchedule() error {
+	if mc.DerivedFrom != "" && (mc.EmitImmediately || mc.Jitter != "" || mc.Align != 0 || mc.RateGenerator != nil) {
+		return fmt.Errorf("metric %q: derived metrics are scheduled by their source", mc.Name)
+	}
+
+	if mc.RateGenerator != nil {
+		if mc.RateGenerator.Value == "" {
+			return fmt.Errorf("metric %q: empty rate_generator value", mc.Name)
+		}
+
+		if err := ValidateGenerator(mc.RateGenerator.Generator); err != nil {
+			return fmt.Errorf("metric %q: rate_generator: %w", mc.Name, err)
+		}
+	}
+
+	if _, err := mc.JitterDuration(); err != nil {
+		return fmt.Errorf("metric %q: %w", mc.Name, err)
+	}
+
+	if mc.Align < 0 {
+		return fmt.Errorf("metric %q: align must be positive, got %s", mc.Name, mc.Align)
 	}
 
 	return nil
 }
 
+// MaxSpeed tells whether the task records its points as fast as possible, which is
+// the case of a rate of 0 without a rate generator.
+func (mc *MetricTask) MaxSpeed() bool {
+	return mc.Rate == 0 && mc.RateGenerator == nil && mc.DerivedFrom == ""
+}
+
+// JitterDuration resolves the jitter of the task, a percentage being relative to its rate.
+func (mc *MetricTask) JitterDuration() (time.Duration, error) {
+	if mc.Jitter == "" {
+		return 0, nil
+	}
+
+	if percent, ok := strings.CutSuffix(mc.Jitter, "%"); ok {
+		ratio, err := strconv.ParseFloat(percent, 64)
+		if err != nil || ratio < 0 || ratio > 100 {
+			return 0, fmt.Errorf("invalid jitter %q, percentages must be between 0%% and 100%%", mc.Jitter)
+		}
+
+		return time.Duration(float64(mc.Rate) * ratio / 100), nil
+	}
+
+	jitter, err := time.ParseDuration(mc.Jitter)
+	if err != nil || jitter < 0 {
+		return 0, fmt.Errorf("invalid jitter %q, must be a positive duration or a percentage of the rate", mc.Jitter)
+	}
+
+	return jitter, nil
+}
+
+// Rate is the time between the data points of a metric task, 0 records them as fast as possible.
+type Rate time.Duration
+
+// UnmarshalYAML reads a duration, or a plain 0 asking for max speed.
+func (r *Rate) UnmarshalYAML(node *yaml.Node) error {
+	if node.Tag == "!!int" && node.Value == "0" {
+		*r = 0
+		return nil
+	}
+
+	var rate time.Duration
+	if err := node.Decode(&rate); err != nil {
+		return err
+	}
+
+	*r = Rate(rate)
+	return nil
+}
+
+func (r Rate) MarshalYAML() (any, error) {
+	return r.String(), nil
+}
+
+func (r Rate) String() string {
+	return time.Duration(r).String()
+}
+
 func (mc *MetricTask) UnmarshalYAML(node *yaml.Node) error {
 	defaultTask := NewMetricTask()
 
@@ -99,7 +311,7 @@ func WithType(valueType string) MetricTaskOption {
 
 func WithRate(rate time.Duration) MetricTaskOption {
 	return func(mt *MetricTask) {
-		mt.Rate = rate
+		mt.Rate = Rate(rate)
 	}
 }
 
@@ -138,3 +350,66 @@ func WithUnit(unit string) MetricTaskOption {
 		mt.Unit = unit
 	}
 }
+
+func WithService(service string) MetricTaskOption {
+	return func(mt *MetricTask) {
+		mt.Service = service
+	}
+}
+
+func WithTemporality(temporality string) MetricTaskOption {
+	return func(mt *MetricTask) {
+		mt.Temporality = temporality
+	}
+}
+
+func WithAggregation(aggregation string) MetricTaskOption {
+	return func(mt *MetricTask) {
+		mt.Aggregation = aggregation
+	}
+}
+
+func WithBuckets(buckets []float64) MetricTaskOption {
+	return func(mt *MetricTask) {
+		mt.Buckets = buckets
+	}
+}
+
+func WithDerivedFrom(source, expr string) MetricTaskOption {
+	return func(mt *MetricTask) {
+		mt.DerivedFrom = source
+		mt.Expression = expr
+	}
+}
+
+func WithLatency(generator, value string) MetricTaskOption {
+	return func(mt *MetricTask) {
+		mt.Latency = &LatencyConfig{Generator: generator, Value: value}
+	}
+}
+
+func WithCounter(counter string) MetricTaskOption {
+	return func(mt *MetricTask) {
+		mt.Counter = counter
+	}
+}
+
+func WithLevel(level bool) MetricTaskOption {
+	return func(mt *MetricTask) {
+		mt.Level = level
+	}
+}
+
+func WithSchedule(emitImmediately bool, jitter string, align time.Duration) MetricTaskOption {
+	return func(mt *MetricTask) {
+		mt.EmitImmediately = emitImmediately
+		mt.Jitter = jitter
+		mt.Align = align
+	}
+}
+
+func WithRateGenerator(generator, value string) MetricTaskOption {
+	return func(mt *MetricTask) {
+		mt.RateGenerator = &RateGeneratorConfig{Generator: generator, Value: value}
+	}
+}
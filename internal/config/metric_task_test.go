@@ -6,6 +6,7 @@ import (
 
 	"github.com/neonmei/szgen/internal/consts"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
 )
 
@@ -15,7 +16,7 @@ func TestNewMetricTask(t *testing.T) {
 		assert.Equal(t, consts.DefaultMetricName, mt.Name)
 		assert.Equal(t, consts.DefaultMetricKind, mt.Kind)
 		assert.Equal(t, consts.DefaultValueType, mt.Type)
-		assert.Equal(t, consts.DefaultRate, mt.Rate)
+		assert.Equal(t, Rate(consts.DefaultRate), mt.Rate)
 		assert.Equal(t, consts.DefaultCount, mt.Count)
 		assert.Equal(t, consts.DefaultValue, mt.Value)
 		assert.Equal(t, consts.DefaultGenerator, mt.Generator)
@@ -38,7 +39,7 @@ func TestNewMetricTask(t *testing.T) {
 		assert.Equal(t, "custom.metric", mt.Name)
 		assert.Equal(t, consts.MetricTypeGauge, mt.Kind)
 		assert.Equal(t, consts.ValueTypeInt64, mt.Type)
-		assert.Equal(t, 5*time.Second, mt.Rate)
+		assert.Equal(t, Rate(5*time.Second), mt.Rate)
 		assert.Equal(t, 100, mt.Count)
 		assert.Equal(t, "50", mt.Value)
 		assert.Equal(t, consts.GeneratorRandom, mt.Generator)
@@ -80,7 +81,7 @@ func TestMetricTask_Validate(t *testing.T) {
 				Kind:      consts.MetricTypeCounter,
 				Type:      consts.ValueTypeFloat64,
 				Generator: consts.GeneratorConstant,
-				Rate:      1 * time.Second,
+				Rate:      Rate(time.Second),
 			},
 			wantErr: false,
 		},
@@ -117,7 +118,7 @@ func TestMetricTask_Validate(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "empty rate",
+			name: "max speed rate",
 			task: MetricTask{
 				Name:      "valid.metric",
 				Kind:      consts.MetricTypeCounter,
@@ -125,6 +126,157 @@ func TestMetricTask_Validate(t *testing.T) {
 				Generator: consts.GeneratorConstant,
 				Rate:      0,
 			},
+			wantErr: false,
+		},
+		{
+			name: "negative rate",
+			task: MetricTask{
+				Name:      "valid.metric",
+				Kind:      consts.MetricTypeCounter,
+				Type:      consts.ValueTypeFloat64,
+				Generator: consts.GeneratorConstant,
+				Rate:      Rate(-time.Second),
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid temporality and aggregation",
+			task: MetricTask{
+				Name:        "valid.metric",
+				Kind:        consts.MetricTypeHistogram,
+				Type:        consts.ValueTypeFloat64,
+				Generator:   consts.GeneratorConstant,
+				Rate:        Rate(time.Second),
+				Temporality: consts.TemporalityCumulative,
+				Aggregation: consts.AggregationExplicitBucketHistogram,
+				Buckets:     []float64{1, 5, 10},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid temporality",
+			task: MetricTask{
+				Name:        "valid.metric",
+				Kind:        consts.MetricTypeCounter,
+				Type:        consts.ValueTypeFloat64,
+				Generator:   consts.GeneratorConstant,
+				Rate:        Rate(time.Second),
+				Temporality: "invalid",
+			},
+			wantErr: true,
+		},
+		{
+			name: "aggregation on non histogram",
+			task: MetricTask{
+				Name:        "valid.metric",
+				Kind:        consts.MetricTypeCounter,
+				Type:        consts.ValueTypeFloat64,
+				Generator:   consts.GeneratorConstant,
+				Rate:        Rate(time.Second),
+				Aggregation: consts.AggregationExponentialHistogram,
+			},
+			wantErr: true,
+		},
+		{
+			name: "buckets without explicit aggregation",
+			task: MetricTask{
+				Name:        "valid.metric",
+				Kind:        consts.MetricTypeHistogram,
+				Type:        consts.ValueTypeFloat64,
+				Generator:   consts.GeneratorConstant,
+				Rate:        Rate(time.Second),
+				Aggregation: consts.AggregationExponentialHistogram,
+				Buckets:     []float64{1, 5, 10},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsorted buckets",
+			task: MetricTask{
+				Name:        "valid.metric",
+				Kind:        consts.MetricTypeHistogram,
+				Type:        consts.ValueTypeFloat64,
+				Generator:   consts.GeneratorConstant,
+				Rate:        Rate(time.Second),
+				Aggregation: consts.AggregationExplicitBucketHistogram,
+				Buckets:     []float64{10, 5, 1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "request-driven histogram",
+			task: *NewMetricTask(WithKind(consts.MetricTypeHistogram), WithLatency(consts.GeneratorRandom, "0.01,0.3"), WithCounter("requests")),
+		},
+		{
+			name:    "latency on non histogram",
+			task:    *NewMetricTask(WithKind(consts.MetricTypeCounter), WithLatency(consts.GeneratorRandom, "0.01,0.3")),
+			wantErr: true,
+		},
+		{
+			name:    "empty latency value",
+			task:    *NewMetricTask(WithKind(consts.MetricTypeHistogram), WithLatency(consts.GeneratorRandom, "")),
+			wantErr: true,
+		},
+		{
+			name:    "invalid latency generator",
+			task:    *NewMetricTask(WithKind(consts.MetricTypeHistogram), WithLatency("chaos", "1")),
+			wantErr: true,
+		},
+		{
+			name:    "counter without latency",
+			task:    *NewMetricTask(WithKind(consts.MetricTypeHistogram), WithCounter("requests")),
+			wantErr: true,
+		},
+		{
+			name:    "counter named as the histogram",
+			task:    *NewMetricTask(WithName("requests"), WithKind(consts.MetricTypeHistogram), WithLatency(consts.GeneratorRandom, "0.01,0.3"), WithCounter("requests")),
+			wantErr: true,
+		},
+		{
+			name: "aligned with jitter",
+			task: *NewMetricTask(WithSchedule(true, "10%", time.Minute)),
+		},
+		{
+			name:    "invalid jitter",
+			task:    *NewMetricTask(WithSchedule(false, "a bit", 0)),
+			wantErr: true,
+		},
+		{
+			name:    "jitter over 100%",
+			task:    *NewMetricTask(WithSchedule(false, "150%", 0)),
+			wantErr: true,
+		},
+		{
+			name:    "negative align",
+			task:    *NewMetricTask(WithSchedule(false, "", -time.Second)),
+			wantErr: true,
+		},
+		{
+			name: "rate generator without rate",
+			task: *NewMetricTask(WithRate(0), WithRateGenerator(consts.GeneratorExponential, "2")),
+		},
+		{
+			name:    "empty rate generator value",
+			task:    *NewMetricTask(WithRateGenerator(consts.GeneratorExponential, "")),
+			wantErr: true,
+		},
+		{
+			name:    "invalid rate generator",
+			task:    *NewMetricTask(WithRateGenerator("poisson", "2")),
+			wantErr: true,
+		},
+		{
+			name:    "scheduled derived metric",
+			task:    *NewMetricTask(WithDerivedFrom("requests", "value"), WithSchedule(true, "", 0)),
+			wantErr: true,
+		},
+		{
+			name: "updowncounter level",
+			task: *NewMetricTask(WithKind(consts.MetricTypeUpDownCounter), WithLevel(true)),
+		},
+		{
+			name:    "counter level",
+			task:    *NewMetricTask(WithKind(consts.MetricTypeCounter), WithLevel(true)),
 			wantErr: true,
 		},
 	}
@@ -156,7 +308,40 @@ kind: gauge
 
 		// Defaults
 		assert.Equal(t, consts.DefaultValueType, mt.Type)
-		assert.Equal(t, consts.DefaultRate, mt.Rate)
+		assert.Equal(t, Rate(consts.DefaultRate), mt.Rate)
+	})
+
+	t.Run("zero rate records at max speed", func(t *testing.T) {
+		var mt MetricTask
+		err := yaml.Unmarshal([]byte("name: bench.metric\nrate: 0\n"), &mt)
+		require.NoError(t, err)
+
+		assert.Zero(t, mt.Rate)
+		assert.True(t, mt.MaxSpeed())
+	})
+
+	t.Run("zero rate leaves the node alone", func(t *testing.T) {
+		var node yaml.Node
+		require.NoError(t, yaml.Unmarshal([]byte("name: bench.metric\nrate: 0\n"), &node))
+
+		var mt MetricTask
+		require.NoError(t, node.Decode(&mt))
+		assert.Equal(t, "!!int", node.Content[0].Content[3].Tag)
+	})
+
+	t.Run("other integer rates are rejected", func(t *testing.T) {
+		var mt MetricTask
+		assert.Error(t, yaml.Unmarshal([]byte("name: bench.metric\nrate: 5\n"), &mt))
+	})
+
+	t.Run("rate round trips", func(t *testing.T) {
+		data, err := yaml.Marshal(MetricTask{Name: "bench.metric", Rate: Rate(1500 * time.Millisecond)})
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "rate: 1.5s")
+
+		var mt MetricTask
+		require.NoError(t, yaml.Unmarshal(data, &mt))
+		assert.Equal(t, Rate(1500*time.Millisecond), mt.Rate)
 	})
 
 	t.Run("full config overrides defaults", func(t *testing.T) {
@@ -180,7 +365,7 @@ attributes:
 		assert.Equal(t, "full.config", mt.Name)
 		assert.Equal(t, "histogram", mt.Kind)
 		assert.Equal(t, "int64", mt.Type)
-		assert.Equal(t, 5*time.Second, mt.Rate)
+		assert.Equal(t, Rate(5*time.Second), mt.Rate)
 		assert.Equal(t, 50, mt.Count)
 		assert.Equal(t, "10", mt.Value)
 		assert.Equal(t, "step", mt.Generator)
@@ -189,3 +374,24 @@ attributes:
 		assert.Equal(t, map[string]any{"env": "prod"}, mt.Attributes)
 	})
 }
+
+func TestMetricTask_JitterDuration(t *testing.T) {
+	tests := []struct {
+		jitter   string
+		expected time.Duration
+	}{
+		{jitter: "", expected: 0},
+		{jitter: "250ms", expected: 250 * time.Millisecond},
+		{jitter: "10%", expected: time.Second},
+		{jitter: "2.5%", expected: 250 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.jitter, func(t *testing.T) {
+			task := NewMetricTask(WithRate(10*time.Second), WithSchedule(false, tt.jitter, 0))
+			jitter, err := task.JitterDuration()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, jitter)
+		})
+	}
+}
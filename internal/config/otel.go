@@ -44,11 +44,43 @@ func NewOTelConfig(serviceVersion string) map[string]any {
 				},
 			},
 		},
+		"tracer_provider": map[string]any{
+			"processors": []map[string]any{
+				{
+					"batch": map[string]any{
+						"exporter": map[string]any{
+							"otlp_grpc": map[string]any{
+								"endpoint":    consts.DefaultOTLPEndpoint,
+								"compression": "gzip",
+								"insecure":    consts.DefaultOTLPInsecure,
+								"timeout":     consts.DefaultOTelTimeoutMillis,
+							},
+						},
+					},
+				},
+			},
+		},
+		"logger_provider": map[string]any{
+			"processors": []map[string]any{
+				{
+					"batch": map[string]any{
+						"exporter": map[string]any{
+							"otlp_grpc": map[string]any{
+								"endpoint":    consts.DefaultOTLPEndpoint,
+								"compression": "gzip",
+								"insecure":    consts.DefaultOTLPInsecure,
+								"timeout":     consts.DefaultOTelTimeoutMillis,
+							},
+						},
+					},
+				},
+			},
+		},
 		"resource": map[string]any{
 			"attributes": []map[string]any{
 				{
 					"name":  "service.name",
-					"value": "szgen",
+					"value": consts.DefaultServiceName,
 					"type":  "string",
 				},
 				{
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// PhaseConfig is a step of the run timeline. While it's active, the named metric tasks
+// draw their values from the overridden generator parameters.
+type PhaseConfig struct {
+	Name     string                   `yaml:"name"`
+	Duration time.Duration            `yaml:"duration"`
+	Tasks    map[string]PhaseOverride `yaml:"tasks,omitempty"`
+}
+
+// PhaseOverride replaces the generator parameters of a task, empty fields keep the
+// task's own configuration.
+type PhaseOverride struct {
+	Generator string `yaml:"generator,omitempty"`
+	Value     string `yaml:"value,omitempty"`
+}
+
+func (pc *PhaseConfig) Validate() error {
+	if pc.Name == "" {
+		return fmt.Errorf("empty phase name")
+	}
+
+	if pc.Duration <= 0 {
+		return fmt.Errorf("phase %q: duration must be positive, got %s", pc.Name, pc.Duration)
+	}
+
+	for task, override := range pc.Tasks {
+		if err := ValidateGenerator(override.Generator); err != nil {
+			return fmt.Errorf("phase %q: task %q: %w", pc.Name, task, err)
+		}
+	}
+
+	return nil
+}
+
+// Apply returns the generator and value a task uses while the phase is active.
+func (pc *PhaseConfig) Apply(task MetricTask) (string, string) {
+	override, ok := pc.Tasks[task.Name]
+	if !ok {
+		return task.Generator, task.Value
+	}
+
+	generator, value := task.Generator, task.Value
+	if override.Generator != "" {
+		generator = override.Generator
+	}
+	if override.Value != "" {
+		value = override.Value
+	}
+
+	return generator, value
+}
+
+// validatePhases rejects phases overriding tasks that are not configured.
+func (c *Config) validatePhases() error {
+	names := c.metricTaskNames()
+	for i, phase := range c.Phases {
+		if err := phase.Validate(); err != nil {
+			return fmt.Errorf("phase[%d]: %w", i, err)
+		}
+
+		for task := range phase.Tasks {
+			if _, ok := names[task]; !ok {
+				return fmt.Errorf("phase[%d]: phase %q: unknown metric task %q", i, phase.Name, task)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/neonmei/szgen/internal/consts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPhaseConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		phase  PhaseConfig
+		errMsg string
+	}{
+		{name: "valid", phase: PhaseConfig{Name: "outage", Duration: time.Minute, Tasks: map[string]PhaseOverride{"errors": {Value: "100"}}}},
+		{name: "empty name", phase: PhaseConfig{Duration: time.Minute}, errMsg: "empty phase name"},
+		{name: "no duration", phase: PhaseConfig{Name: "outage"}, errMsg: "duration must be positive"},
+		{name: "invalid generator", phase: PhaseConfig{Name: "outage", Duration: time.Minute, Tasks: map[string]PhaseOverride{"errors": {Generator: "chaos"}}}, errMsg: "invalid generator"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.phase.Validate()
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestPhaseConfig_Apply(t *testing.T) {
+	task := MetricTask{Name: "latency", Generator: consts.GeneratorRandom, Value: "10,50"}
+	phase := PhaseConfig{Name: "degraded", Duration: time.Minute, Tasks: map[string]PhaseOverride{
+		"latency": {Value: "200,900"},
+		"errors":  {Generator: consts.GeneratorConstant, Value: "5"},
+	}}
+
+	generator, value := phase.Apply(task)
+	assert.Equal(t, consts.GeneratorRandom, generator)
+	assert.Equal(t, "200,900", value)
+
+	generator, value = phase.Apply(MetricTask{Name: "errors", Generator: consts.GeneratorSine, Value: "1"})
+	assert.Equal(t, consts.GeneratorConstant, generator)
+	assert.Equal(t, "5", value)
+
+	generator, value = phase.Apply(MetricTask{Name: "requests", Generator: consts.GeneratorStep, Value: "0,1"})
+	assert.Equal(t, consts.GeneratorStep, generator)
+	assert.Equal(t, "0,1", value)
+}
+
+func TestConfig_ValidatePhases(t *testing.T) {
+	newConfig := func(phases ...PhaseConfig) *Config {
+		return &Config{
+			Metrics: &MetricsConfig{Tasks: []MetricTask{*NewMetricTask(WithName("errors"))}},
+			Phases:  phases,
+		}
+	}
+
+	t.Run("known task", func(t *testing.T) {
+		cfg := newConfig(PhaseConfig{Name: "outage", Duration: time.Minute, Tasks: map[string]PhaseOverride{"errors": {Value: "100"}}})
+		assert.NoError(t, cfg.validatePhases())
+	})
+
+	t.Run("unknown task", func(t *testing.T) {
+		cfg := newConfig(PhaseConfig{Name: "outage", Duration: time.Minute, Tasks: map[string]PhaseOverride{"latency": {Value: "100"}}})
+		err := cfg.validatePhases()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `phase[0]: phase "outage": unknown metric task "latency"`)
+	})
+
+	t.Run("cluster task", func(t *testing.T) {
+		cfg := newConfig(PhaseConfig{Name: "pressure", Duration: time.Minute, Tasks: map[string]PhaseOverride{"k8s.pod.memory.usage": {Value: "1,2"}}})
+		cfg.Cluster = &ClusterConfig{}
+		assert.NoError(t, cfg.validatePhases())
+	})
+
+	t.Run("invalid phase", func(t *testing.T) {
+		cfg := newConfig(PhaseConfig{Name: "outage"})
+		assert.ErrorContains(t, cfg.validatePhases(), "phase[0]")
+	})
+}
@@ -0,0 +1,122 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/neonmei/szgen/internal/consts"
+)
+
+// RampConfig drives the ramp executor: the amount of active tasks grows linearly from
+// Start to End during RampUp, stays at End during Hold and shrinks back to Start during
+// RampDown, after which the run ends. An End of 0 means every task.
+type RampConfig struct {
+	Start    int
+	End      int
+	RampUp   time.Duration
+	Hold     time.Duration
+	RampDown time.Duration
+}
+
+// Ramp reads the ramp stages from the executor params.
+func (ec *ExecutorConfig) Ramp() (RampConfig, error) {
+	var (
+		rc  RampConfig
+		err error
+	)
+
+	if rc.Start, err = intParam(ec.Params, consts.ParamRampStart, 1); err != nil {
+		return rc, err
+	}
+
+	if rc.End, err = intParam(ec.Params, consts.ParamRampEnd, 0); err != nil {
+		return rc, err
+	}
+
+	durations := []struct {
+		key   string
+		value *time.Duration
+	}{
+		{consts.ParamRampUp, &rc.RampUp},
+		{consts.ParamRampHold, &rc.Hold},
+		{consts.ParamRampDown, &rc.RampDown},
+	}
+
+	for _, d := range durations {
+		if *d.value, err = durationParam(ec.Params, d.key); err != nil {
+			return rc, err
+		}
+	}
+
+	return rc, rc.Validate()
+}
+
+func (rc RampConfig) Validate() error {
+	if rc.Start < 0 {
+		return fmt.Errorf("executor: %s must be positive, got %d", consts.ParamRampStart, rc.Start)
+	}
+
+	if rc.End < 0 {
+		return fmt.Errorf("executor: %s must be positive, got %d", consts.ParamRampEnd, rc.End)
+	}
+
+	if rc.End > 0 && rc.Start > rc.End {
+		return fmt.Errorf("executor: %s %d must not be greater than %s %d", consts.ParamRampStart, rc.Start, consts.ParamRampEnd, rc.End)
+	}
+
+	if rc.RampUp < 0 || rc.Hold < 0 || rc.RampDown < 0 {
+		return fmt.Errorf("executor: ramp durations must be positive")
+	}
+
+	if rc.Duration() == 0 {
+		return fmt.Errorf("executor: ramp requires at least one of %s, %s or %s", consts.ParamRampUp, consts.ParamRampHold, consts.ParamRampDown)
+	}
+
+	return nil
+}
+
+// Duration is the length of the whole ramp.
+func (rc RampConfig) Duration() time.Duration {
+	return rc.RampUp + rc.Hold + rc.RampDown
+}
+
+func intParam(params map[string]any, key string, fallback int) (int, error) {
+	val, ok := params[key]
+	if !ok {
+		return fallback, nil
+	}
+
+	switch v := val.(type) {
+	case int:
+		return v, nil
+	case float64:
+		return int(v), nil
+	default:
+		return 0, fmt.Errorf("executor: %s must be an integer, got %v", key, val)
+	}
+}
+
+// durationParam accepts Go durations ("30s", "1m30s") or a number of seconds.
+func durationParam(params map[string]any, key string) (time.Duration, error) {
+	val, ok := params[key]
+	if !ok {
+		return 0, nil
+	}
+
+	switch v := val.(type) {
+	case time.Duration:
+		return v, nil
+	case int:
+		return time.Duration(v) * time.Second, nil
+	case float64:
+		return time.Duration(v * float64(time.Second)), nil
+	case string:
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return 0, fmt.Errorf("executor: invalid %s %q: %w", key, v, err)
+		}
+		return d, nil
+	default:
+		return 0, fmt.Errorf("executor: %s must be a duration, got %v", key, val)
+	}
+}
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/neonmei/szgen/internal/consts"
+)
+
+// ReplicasConfig instantiates every task once per simulated service instance.
+// Attributes are text/template strings rendered with the replica Index (0-based)
+// and the Service name, and identify each instance on its resource.
+type ReplicasConfig struct {
+	Count         int               `yaml:"count,omitempty"`
+	ShareExporter bool              `yaml:"share_exporter,omitempty"`
+	Attributes    map[string]string `yaml:"attributes,omitempty"`
+}
+
+type replicaTemplateData struct {
+	Index   int
+	Service string
+}
+
+func (rc *ReplicasConfig) Validate() error {
+	if rc.Count < 0 {
+		return fmt.Errorf("replicas: count must be positive, got %d", rc.Count)
+	}
+
+	for name, value := range rc.Attributes {
+		if _, err := template.New(name).Parse(value); err != nil {
+			return fmt.Errorf("replicas: attribute %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// Enabled reports whether tasks should be instantiated more than once.
+func (rc *ReplicasConfig) Enabled() bool {
+	return rc.Count > 1
+}
+
+// InstanceAttributes renders the attributes identifying a replica of a service.
+func (rc *ReplicasConfig) InstanceAttributes(service string, index int) (map[string]any, error) {
+	templates := rc.Attributes
+	if len(templates) == 0 {
+		templates = map[string]string{
+			"service.instance.id": consts.DefaultReplicaInstanceID,
+			"host.name":           consts.DefaultReplicaHostName,
+		}
+	}
+
+	data := replicaTemplateData{Index: index, Service: service}
+	attrs := make(map[string]any, len(templates))
+	for name, value := range templates {
+		tmpl, err := template.New(name).Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("replicas: attribute %q: %w", name, err)
+		}
+
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, data); err != nil {
+			return nil, fmt.Errorf("replicas: attribute %q: %w", name, err)
+		}
+		attrs[name] = sb.String()
+	}
+
+	return attrs, nil
+}
+
+// ExpandReplicas instantiates the configured tasks once per replica, each replica
+// recording through its own metric stream and resource.
+func (c *Config) ExpandReplicas() {
+	if !c.Replicas.Enabled() || c.Metrics == nil {
+		return
+	}
+
+	tasks := make([]MetricTask, 0, len(c.Metrics.Tasks)*c.Replicas.Count)
+	for index := range c.Replicas.Count {
+		for _, task := range c.Metrics.Tasks {
+			task.Replica = index + 1
+			tasks = append(tasks, task)
+		}
+	}
+
+	c.Metrics.Tasks = tasks
+}
+
+// serviceName resolves the service.name a task is exported under.
+func (c *Config) serviceName(service string) string {
+	if service != "" {
+		return service
+	}
+
+	if name, ok := resourceAttribute(c.OpenTelemetry, "service.name"); ok {
+		return fmt.Sprint(name)
+	}
+
+	return consts.DefaultServiceName
+}
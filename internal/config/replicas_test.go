@@ -0,0 +1,95 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplicasConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     ReplicasConfig
+		wantErr bool
+	}{
+		{name: "disabled", cfg: ReplicasConfig{}},
+		{name: "valid", cfg: ReplicasConfig{Count: 3, Attributes: map[string]string{"host.name": "host-{{ .Index }}"}}},
+		{name: "negative count", cfg: ReplicasConfig{Count: -1}, wantErr: true},
+		{name: "invalid template", cfg: ReplicasConfig{Count: 2, Attributes: map[string]string{"host.name": "{{ .Index"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestReplicasConfig_InstanceAttributes(t *testing.T) {
+	t.Run("default attributes", func(t *testing.T) {
+		rc := ReplicasConfig{Count: 2}
+		attrs, err := rc.InstanceAttributes("checkout", 1)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{
+			"service.instance.id": "checkout-1",
+			"host.name":           "checkout-host-1",
+		}, attrs)
+	})
+
+	t.Run("templated attributes", func(t *testing.T) {
+		rc := ReplicasConfig{Count: 2, Attributes: map[string]string{"k8s.pod.name": "{{ .Service }}-pod-{{ .Index }}"}}
+		attrs, err := rc.InstanceAttributes("checkout", 0)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"k8s.pod.name": "checkout-pod-0"}, attrs)
+	})
+}
+
+func TestConfig_ExpandReplicas(t *testing.T) {
+	t.Run("expands every task", func(t *testing.T) {
+		cfg := &Config{
+			Metrics:  &MetricsConfig{Tasks: []MetricTask{{Name: "a"}, {Name: "b"}}},
+			Replicas: ReplicasConfig{Count: 3},
+		}
+		cfg.ExpandReplicas()
+
+		require.Len(t, cfg.Metrics.Tasks, 6)
+		replicas := make(map[int]int)
+		for _, task := range cfg.Metrics.Tasks {
+			replicas[task.Replica]++
+		}
+		assert.Equal(t, map[int]int{1: 2, 2: 2, 3: 2}, replicas)
+	})
+
+	t.Run("single replica is a no-op", func(t *testing.T) {
+		cfg := &Config{
+			Metrics:  &MetricsConfig{Tasks: []MetricTask{{Name: "a"}}},
+			Replicas: ReplicasConfig{Count: 1},
+		}
+		cfg.ExpandReplicas()
+
+		require.Len(t, cfg.Metrics.Tasks, 1)
+		assert.Zero(t, cfg.Metrics.Tasks[0].Replica)
+	})
+}
+
+func TestConfig_StreamOTelConfig_Replica(t *testing.T) {
+	cfg, err := NewConfig(WithDefaultConfig("test"), WithReplicas(ReplicasConfig{Count: 2}))
+	require.NoError(t, err)
+
+	otelCfg, err := cfg.StreamOTelConfig(MetricStream{Replica: 2}, nil)
+	require.NoError(t, err)
+
+	instanceID, ok := resourceAttribute(otelCfg, "service.instance.id")
+	require.True(t, ok)
+	assert.Equal(t, "szgen-1", instanceID)
+
+	serviceName, ok := resourceAttribute(otelCfg, "service.name")
+	require.True(t, ok)
+	assert.Equal(t, "szgen", serviceName)
+}
@@ -0,0 +1,178 @@
+package config
+
+import (
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/neonmei/szgen/internal/consts"
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	ScenarioTaskOption func(*ScenarioTask)
+
+	// ScenarioTask simulates Count requests, one every Rate, correlating every signal of a request:
+	// a span named Name lasting the generated latency (milliseconds), a Metric histogram observation
+	// recorded within the span so it carries an exemplar, and a log record rendered from Body with the
+	// request Name, Index, Latency and Error, sharing the span trace and span IDs.
+	ScenarioTask struct {
+		Name       string         `yaml:"name"`
+		Metric     string         `yaml:"metric,omitempty"`
+		Unit       string         `yaml:"unit,omitempty"`
+		Rate       time.Duration  `yaml:"rate,omitempty"`
+		Count      int            `yaml:"count,omitempty"`
+		Value      string         `yaml:"value,omitempty"`
+		Generator  string         `yaml:"generator,omitempty"`
+		SpanKind   string         `yaml:"span_kind,omitempty"`
+		Body       string         `yaml:"body,omitempty"`
+		Attributes map[string]any `yaml:"attributes,omitempty"`
+		SpanModel  `yaml:",inline"`
+	}
+)
+
+func NewScenarioTask(options ...ScenarioTaskOption) *ScenarioTask {
+	st := &ScenarioTask{
+		Name:      consts.DefaultScenarioName,
+		Metric:    consts.DefaultScenarioMetric,
+		Unit:      consts.DefaultScenarioUnit,
+		Rate:      consts.DefaultRate,
+		Count:     consts.DefaultCount,
+		Value:     consts.DefaultSpanDuration,
+		Generator: consts.DefaultGenerator,
+		SpanKind:  consts.SpanKindServer,
+		Body:      consts.DefaultScenarioBody,
+	}
+
+	for _, option := range options {
+		option(st)
+	}
+
+	return st
+}
+
+// MetricTask is the latency histogram task the scenario records through.
+func (st *ScenarioTask) MetricTask() MetricTask {
+	return MetricTask{
+		Name:        st.Metric,
+		Kind:        consts.MetricTypeHistogram,
+		Type:        consts.ValueTypeFloat64,
+		Rate:        Rate(st.Rate),
+		Count:       st.Count,
+		Value:       st.Value,
+		Generator:   st.Generator,
+		Description: fmt.Sprintf("Latency of %s generated with szgen", st.Name),
+		Unit:        st.Unit,
+		Attributes:  st.Attributes,
+	}
+}
+
+func (st *ScenarioTask) Validate() error {
+	if st.Name == "" {
+		return fmt.Errorf("empty scenario name")
+	}
+
+	mt := st.MetricTask()
+	if err := mt.Validate(); err != nil {
+		return fmt.Errorf("scenario %q: %w", st.Name, err)
+	}
+
+	if err := ValidateSpanKind(st.SpanKind); err != nil {
+		return fmt.Errorf("scenario %q: %w", st.Name, err)
+	}
+
+	if err := st.SpanModel.Validate(); err != nil {
+		return fmt.Errorf("scenario %q: %w", st.Name, err)
+	}
+
+	if _, err := template.New(st.Name).Parse(st.Body); err != nil {
+		return fmt.Errorf("scenario %q: invalid body template: %w", st.Name, err)
+	}
+
+	return nil
+}
+
+func (st *ScenarioTask) UnmarshalYAML(node *yaml.Node) error {
+	defaultTask := NewScenarioTask()
+
+	type rawScenarioTask ScenarioTask
+	if err := node.Decode((*rawScenarioTask)(defaultTask)); err != nil {
+		return err
+	}
+
+	*st = *defaultTask
+
+	return nil
+}
+
+func WithScenarioName(name string) ScenarioTaskOption {
+	return func(st *ScenarioTask) {
+		st.Name = name
+	}
+}
+
+func WithScenarioMetric(metric string) ScenarioTaskOption {
+	return func(st *ScenarioTask) {
+		st.Metric = metric
+	}
+}
+
+func WithScenarioUnit(unit string) ScenarioTaskOption {
+	return func(st *ScenarioTask) {
+		st.Unit = unit
+	}
+}
+
+func WithScenarioRate(rate time.Duration) ScenarioTaskOption {
+	return func(st *ScenarioTask) {
+		st.Rate = rate
+	}
+}
+
+func WithScenarioCount(count int) ScenarioTaskOption {
+	return func(st *ScenarioTask) {
+		st.Count = count
+	}
+}
+
+func WithScenarioValue(value string) ScenarioTaskOption {
+	return func(st *ScenarioTask) {
+		st.Value = value
+	}
+}
+
+func WithScenarioGenerator(generator string) ScenarioTaskOption {
+	return func(st *ScenarioTask) {
+		st.Generator = generator
+	}
+}
+
+func WithScenarioSpanKind(kind string) ScenarioTaskOption {
+	return func(st *ScenarioTask) {
+		st.SpanKind = kind
+	}
+}
+
+func WithScenarioErrorRatio(ratio float64) ScenarioTaskOption {
+	return func(st *ScenarioTask) {
+		st.ErrorRatio = ratio
+	}
+}
+
+func WithScenarioSpanModel(model SpanModel) ScenarioTaskOption {
+	return func(st *ScenarioTask) {
+		st.SpanModel = model
+	}
+}
+
+func WithScenarioBody(body string) ScenarioTaskOption {
+	return func(st *ScenarioTask) {
+		st.Body = body
+	}
+}
+
+func WithScenarioAttributes(attrs map[string]any) ScenarioTaskOption {
+	return func(st *ScenarioTask) {
+		st.Attributes = attrs
+	}
+}
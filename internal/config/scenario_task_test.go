@@ -0,0 +1,105 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/neonmei/szgen/internal/consts"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestNewScenarioTask(t *testing.T) {
+	st := NewScenarioTask()
+	assert.Equal(t, consts.DefaultScenarioName, st.Name)
+	assert.Equal(t, consts.DefaultScenarioMetric, st.Metric)
+	assert.Equal(t, consts.DefaultScenarioUnit, st.Unit)
+	assert.Equal(t, consts.DefaultRate, st.Rate)
+	assert.Equal(t, consts.DefaultCount, st.Count)
+	assert.Equal(t, consts.DefaultSpanDuration, st.Value)
+	assert.Equal(t, consts.DefaultGenerator, st.Generator)
+	assert.Equal(t, consts.SpanKindServer, st.SpanKind)
+	assert.Equal(t, consts.DefaultScenarioBody, st.Body)
+	assert.NoError(t, st.Validate())
+}
+
+func TestScenarioTask_MetricTask(t *testing.T) {
+	st := NewScenarioTask(
+		WithScenarioName("GET /users"),
+		WithScenarioMetric("http.server.request.duration"),
+		WithScenarioUnit("s"),
+		WithScenarioRate(time.Second),
+		WithScenarioCount(10),
+		WithScenarioValue("0.01,0.5"),
+		WithScenarioGenerator(consts.GeneratorRandom),
+		WithScenarioAttributes(map[string]any{"http.route": "/users"}),
+	)
+
+	mt := st.MetricTask()
+	assert.Equal(t, "http.server.request.duration", mt.Name)
+	assert.Equal(t, consts.MetricTypeHistogram, mt.Kind)
+	assert.Equal(t, consts.ValueTypeFloat64, mt.Type)
+	assert.Equal(t, "s", mt.Unit)
+	assert.Equal(t, Rate(time.Second), mt.Rate)
+	assert.Equal(t, 10, mt.Count)
+	assert.Equal(t, "0.01,0.5", mt.Value)
+	assert.Equal(t, consts.GeneratorRandom, mt.Generator)
+	assert.Equal(t, map[string]any{"http.route": "/users"}, mt.Attributes)
+}
+
+func TestScenarioTask_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		task    *ScenarioTask
+		wantErr string
+	}{
+		{
+			name:    "empty name",
+			task:    NewScenarioTask(WithScenarioName("")),
+			wantErr: "empty scenario name",
+		},
+		{
+			name:    "invalid metric name",
+			task:    NewScenarioTask(WithScenarioMetric("1invalid")),
+			wantErr: "invalid metric name",
+		},
+		{
+			name:    "invalid span kind",
+			task:    NewScenarioTask(WithScenarioSpanKind("gateway")),
+			wantErr: "invalid span kind",
+		},
+		{
+			name:    "error ratio out of range",
+			task:    NewScenarioTask(WithScenarioErrorRatio(-0.1)),
+			wantErr: "error_ratio",
+		},
+		{
+			name:    "invalid body template",
+			task:    NewScenarioTask(WithScenarioBody("{{ .Latency")),
+			wantErr: "invalid body template",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorContains(t, tt.task.Validate(), tt.wantErr)
+		})
+	}
+}
+
+func TestScenarioTask_UnmarshalYAML(t *testing.T) {
+	data := `
+name: "GET /checkout"
+metric: http.server.request.duration
+error_ratio: 0.1
+`
+	var st ScenarioTask
+	err := yaml.Unmarshal([]byte(data), &st)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "GET /checkout", st.Name)
+	assert.Equal(t, "http.server.request.duration", st.Metric)
+	assert.Equal(t, 0.1, st.ErrorRatio)
+	assert.Equal(t, consts.SpanKindServer, st.SpanKind)
+	assert.Equal(t, consts.DefaultScenarioUnit, st.Unit)
+}
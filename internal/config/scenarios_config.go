@@ -0,0 +1,5 @@
+package config
+
+type ScenariosConfig struct {
+	Tasks []ScenarioTask `yaml:"tasks"`
+}
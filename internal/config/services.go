@@ -0,0 +1,105 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// ServiceConfig describes a simulated service. Tasks referencing it record through
+// their own MeterProvider, with the service resource on top of the configured one.
+type ServiceConfig struct {
+	Name       string         `yaml:"name"`
+	Attributes map[string]any `yaml:"attributes,omitempty"`
+}
+
+func (sc *ServiceConfig) Validate() error {
+	if sc.Name == "" {
+		return fmt.Errorf("empty service name")
+	}
+
+	return nil
+}
+
+// Service looks up a service by name.
+func (c *Config) Service(name string) (ServiceConfig, bool) {
+	idx := slices.IndexFunc(c.Services, func(sc ServiceConfig) bool { return sc.Name == name })
+	if idx < 0 {
+		return ServiceConfig{}, false
+	}
+
+	return c.Services[idx], true
+}
+
+func validateServices(services []ServiceConfig) error {
+	seen := make(map[string]struct{}, len(services))
+
+	for i, service := range services {
+		if err := service.Validate(); err != nil {
+			return fmt.Errorf("service[%d]: %w", i, err)
+		}
+
+		if _, ok := seen[service.Name]; ok {
+			return fmt.Errorf("service[%d]: duplicate service %q", i, service.Name)
+		}
+		seen[service.Name] = struct{}{}
+	}
+
+	return nil
+}
+
+// resourceAttribute looks up an attribute of the otelconf resource.
+func resourceAttribute(otelCfg map[string]any, name string) (any, bool) {
+	res, _ := otelCfg["resource"].(map[string]any)
+
+	var attrs []map[string]any
+	switch v := res["attributes"].(type) {
+	case []map[string]any:
+		attrs = v
+	case []any:
+		for _, attr := range v {
+			if a, ok := attr.(map[string]any); ok {
+				attrs = append(attrs, a)
+			}
+		}
+	}
+
+	for _, attr := range attrs {
+		if attr["name"] == name {
+			return attr["value"], true
+		}
+	}
+
+	return nil, false
+}
+
+// setResourceAttributes overlays attributes on the otelconf resource, replacing
+// any attribute with the same name.
+func setResourceAttributes(otelCfg map[string]any, attrs map[string]any) {
+	res, ok := otelCfg["resource"].(map[string]any)
+	if !ok {
+		res = make(map[string]any)
+		otelCfg["resource"] = res
+	}
+
+	var current []any
+	switch v := res["attributes"].(type) {
+	case []map[string]any:
+		for _, attr := range v {
+			current = append(current, attr)
+		}
+	case []any:
+		current = v
+	}
+	current = slices.DeleteFunc(current, func(attr any) bool {
+		a, _ := attr.(map[string]any)
+		name, _ := a["name"].(string)
+		_, overridden := attrs[name]
+		return overridden
+	})
+
+	for _, name := range slices.Sorted(maps.Keys(attrs)) {
+		current = append(current, map[string]any{"name": name, "value": attrs[name]})
+	}
+	res["attributes"] = current
+}
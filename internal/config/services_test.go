@@ -0,0 +1,83 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateServices(t *testing.T) {
+	tests := []struct {
+		name     string
+		services []ServiceConfig
+		wantErr  string
+	}{
+		{
+			name:     "no services",
+			services: nil,
+		},
+		{
+			name:     "valid services",
+			services: []ServiceConfig{{Name: "checkout"}, {Name: "payments"}},
+		},
+		{
+			name:     "empty name",
+			services: []ServiceConfig{{Name: ""}},
+			wantErr:  "empty service name",
+		},
+		{
+			name:     "duplicate name",
+			services: []ServiceConfig{{Name: "checkout"}, {Name: "checkout"}},
+			wantErr:  "duplicate service",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateServices(tt.services)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfig_Service(t *testing.T) {
+	cfg := &Config{Services: []ServiceConfig{{Name: "checkout", Attributes: map[string]any{"team": "a"}}}}
+
+	service, ok := cfg.Service("checkout")
+	assert.True(t, ok)
+	assert.Equal(t, "a", service.Attributes["team"])
+
+	_, ok = cfg.Service("unknown")
+	assert.False(t, ok)
+}
+
+func TestConfig_StreamOTelConfig_Service(t *testing.T) {
+	cfg, err := NewConfig(WithDefaultConfig("test"), WithServices([]ServiceConfig{{
+		Name:       "checkout",
+		Attributes: map[string]any{"service.version": "2.0.0", "deployment.environment": "prod"},
+	}}))
+	require.NoError(t, err)
+
+	otelCfg, err := cfg.StreamOTelConfig(MetricStream{Service: "checkout"}, nil)
+	require.NoError(t, err)
+
+	attrs := make(map[string]any)
+	for _, attr := range otelCfg["resource"].(map[string]any)["attributes"].([]any) {
+		a := attr.(map[string]any)
+		attrs[a["name"].(string)] = a["value"]
+	}
+
+	assert.Equal(t, map[string]any{
+		"service.name":           "checkout",
+		"service.version":        "2.0.0",
+		"deployment.environment": "prod",
+	}, attrs)
+
+	_, err = cfg.StreamOTelConfig(MetricStream{Service: "unknown"}, nil)
+	assert.ErrorContains(t, err, "unknown service")
+}
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/neonmei/szgen/internal/consts"
+)
+
+// ShardConfig splits the tasks of a configuration between Count processes, the one with
+// the given Index (0-based) running its share only. Tasks are dealt whole, the series of
+// a single task always run in the same process.
+type ShardConfig struct {
+	Index int
+	Count int
+}
+
+func (sc *ShardConfig) Validate() error {
+	if sc.Count < 0 {
+		return fmt.Errorf("shard: count must not be negative, got %d", sc.Count)
+	}
+
+	if sc.Index < 0 || (sc.Count > 0 && sc.Index >= sc.Count) || (sc.Count == 0 && sc.Index > 0) {
+		return fmt.Errorf("shard: index must be between 0 and %d, got %d", max(sc.Count-1, 0), sc.Index)
+	}
+
+	return nil
+}
+
+// Enabled reports whether tasks are split between processes.
+func (sc *ShardConfig) Enabled() bool {
+	return sc.Count > 1
+}
+
+// metricShardKey identifies the metric tasks that go to the same shard: a replica or
+// pod as a whole, otherwise a source along with the metrics derived from it.
+type metricShardKey struct {
+	source  string
+	replica int
+	pod     int
+}
+
+// ExpandShard keeps the tasks of the shard only, meant to run after replicas and cluster
+// were expanded. Tasks are dealt round robin in configuration order, so every process
+// of a run ends up with the same partition, and the shard index is added to the resource.
+func (c *Config) ExpandShard() {
+	if !c.Shard.Enabled() {
+		return
+	}
+
+	next := 0
+	deal := func() bool {
+		mine := next%c.Shard.Count == c.Shard.Index
+		next++
+		return mine
+	}
+
+	if c.Metrics != nil {
+		shards := make(map[metricShardKey]bool)
+		var tasks []MetricTask
+		for _, task := range c.Metrics.Tasks {
+			key := c.metricShardKey(task)
+			mine, ok := shards[key]
+			if !ok {
+				mine = deal()
+				shards[key] = mine
+			}
+
+			if mine {
+				tasks = append(tasks, task)
+			}
+		}
+		c.Metrics.Tasks = tasks
+	}
+
+	if c.Traces != nil {
+		c.Traces.Tasks = dealTasks(c.Traces.Tasks, deal)
+	}
+
+	if c.Logs != nil {
+		c.Logs.Tasks = dealTasks(c.Logs.Tasks, deal)
+	}
+
+	if c.Scenarios != nil {
+		c.Scenarios.Tasks = dealTasks(c.Scenarios.Tasks, deal)
+	}
+
+	if c.OpenTelemetry != nil {
+		setResourceAttributes(c.OpenTelemetry, map[string]any{consts.ShardIndexAttribute: c.Shard.Index})
+	}
+}
+
+func (c *Config) metricShardKey(task MetricTask) metricShardKey {
+	if task.Replica > 0 || task.Pod > 0 {
+		return metricShardKey{replica: task.Replica, pod: task.Pod}
+	}
+
+	// derived metrics are recorded by their source, cycles are rejected by Validate
+	source := task
+	for range len(c.Metrics.Tasks) {
+		if source.DerivedFrom == "" {
+			break
+		}
+
+		parent, ok := c.metricTask(source.DerivedFrom)
+		if !ok {
+			break
+		}
+		source = parent
+	}
+
+	return metricShardKey{source: source.Name}
+}
+
+func (c *Config) metricTask(name string) (MetricTask, bool) {
+	for _, task := range c.MetricTasks() {
+		if task.Name == name {
+			return task, true
+		}
+	}
+
+	return MetricTask{}, false
+}
+
+func dealTasks[T any](tasks []T, deal func() bool) []T {
+	var mine []T
+	for _, task := range tasks {
+		if deal() {
+			mine = append(mine, task)
+		}
+	}
+
+	return mine
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/neonmei/szgen/internal/consts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     ShardConfig
+		wantErr bool
+	}{
+		{name: "disabled", cfg: ShardConfig{}},
+		{name: "valid", cfg: ShardConfig{Index: 2, Count: 3}},
+		{name: "negative count", cfg: ShardConfig{Count: -1}, wantErr: true},
+		{name: "negative index", cfg: ShardConfig{Index: -1, Count: 3}, wantErr: true},
+		{name: "index beyond count", cfg: ShardConfig{Index: 3, Count: 3}, wantErr: true},
+		{name: "index without count", cfg: ShardConfig{Index: 1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfig_ExpandShard(t *testing.T) {
+	newConfig := func(t *testing.T, shard ShardConfig) *Config {
+		t.Helper()
+
+		cfg, err := NewConfig(WithDefaultConfig("test"),
+			WithMetricsConfig(&MetricsConfig{Tasks: []MetricTask{
+				{Name: "a"},
+				{Name: "b"},
+				{Name: "b.derived", DerivedFrom: "b"},
+				{Name: "b.derived.twice", DerivedFrom: "b.derived"},
+				{Name: "c"},
+			}}),
+			WithTracesConfig(&TracesConfig{Tasks: []TraceTask{{Name: "trace"}}}),
+		)
+		require.NoError(t, err)
+		cfg.Shard = shard
+
+		return cfg
+	}
+
+	t.Run("every task runs on exactly one shard", func(t *testing.T) {
+		shards := make([][]string, 3)
+		seen := make(map[string]int)
+		for index := range shards {
+			cfg := newConfig(t, ShardConfig{Index: index, Count: 3})
+			cfg.ExpandShard()
+
+			for _, task := range cfg.MetricTasks() {
+				shards[index] = append(shards[index], task.Name)
+				seen[task.Name]++
+			}
+			for _, task := range cfg.TraceTasks() {
+				shards[index] = append(shards[index], task.Name)
+				seen[task.Name]++
+			}
+		}
+
+		assert.Equal(t, [][]string{{"a", "trace"}, {"b", "b.derived", "b.derived.twice"}, {"c"}}, shards, "derived metrics follow their source")
+		assert.Len(t, seen, 6)
+		for name, count := range seen {
+			assert.Equal(t, 1, count, name)
+		}
+	})
+
+	t.Run("replicas are not split", func(t *testing.T) {
+		cfg := newConfig(t, ShardConfig{Index: 1, Count: 2})
+		cfg.Replicas.Count = 4
+		cfg.ExpandReplicas()
+		cfg.ExpandShard()
+
+		replicas := make(map[int]int)
+		for _, task := range cfg.MetricTasks() {
+			replicas[task.Replica]++
+		}
+		assert.Equal(t, map[int]int{2: 5, 4: 5}, replicas)
+	})
+
+	t.Run("shard index on the resource", func(t *testing.T) {
+		cfg := newConfig(t, ShardConfig{Index: 1, Count: 2})
+		cfg.ExpandShard()
+
+		index, ok := resourceAttribute(cfg.OpenTelemetry, consts.ShardIndexAttribute)
+		require.True(t, ok)
+		assert.Equal(t, 1, index)
+
+		_, ok = resourceAttribute(cfg.OpenTelemetry, "service.name")
+		assert.True(t, ok, "the configured resource is kept")
+	})
+
+	t.Run("single shard is a no-op", func(t *testing.T) {
+		cfg := newConfig(t, ShardConfig{Count: 1})
+		cfg.ExpandShard()
+
+		assert.Len(t, cfg.MetricTasks(), 5)
+		_, ok := resourceAttribute(cfg.OpenTelemetry, consts.ShardIndexAttribute)
+		assert.False(t, ok)
+	})
+}
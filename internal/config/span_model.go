@@ -0,0 +1,121 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/neonmei/szgen/internal/consts"
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	// SpanModel describes what generated spans carry besides their timing. Spans fail either
+	// with ErrorRatio probability or when their Status code is an error, Events are added
+	// with a probability and root spans link to traces generated earlier by the same task.
+	// A non-zero Seed makes every random decision, durations and IDs reproducible.
+	SpanModel struct {
+		ErrorRatio float64     `yaml:"error_ratio,omitempty"`
+		Status     *SpanStatus `yaml:"status,omitempty"`
+		Events     []SpanEvent `yaml:"events,omitempty"`
+		Links      SpanLinks   `yaml:"links,omitempty"`
+		Seed       uint64      `yaml:"seed,omitempty"`
+	}
+
+	// SpanStatus draws a status code for every span from a generator and records it as
+	// Attribute. Codes from 500, or from 400 on client spans, set the span status to error.
+	SpanStatus struct {
+		Generator string `yaml:"generator"`
+		Value     string `yaml:"value"`
+		Attribute string `yaml:"attribute,omitempty"`
+	}
+
+	// SpanEvent is added to spans with Probability (1 when omitted), optionally only on
+	// some tree Levels (the root being 0) or only on spans with error status.
+	SpanEvent struct {
+		Name        string         `yaml:"name"`
+		Probability float64        `yaml:"probability,omitempty"`
+		Levels      []int          `yaml:"levels,omitempty"`
+		OnError     bool           `yaml:"on_error,omitempty"`
+		Attributes  map[string]any `yaml:"attributes,omitempty"`
+	}
+
+	// SpanLinks links root spans, with Probability (1 when omitted), to Count traces
+	// picked among the ones generated earlier by the same task.
+	SpanLinks struct {
+		Count       int     `yaml:"count,omitempty"`
+		Probability float64 `yaml:"probability,omitempty"`
+	}
+)
+
+func (sm *SpanModel) Validate() error {
+	if err := ValidateRatio("error_ratio", sm.ErrorRatio); err != nil {
+		return err
+	}
+
+	if sm.Status != nil {
+		if sm.ErrorRatio > 0 {
+			return fmt.Errorf("error_ratio and status are mutually exclusive")
+		}
+
+		if err := sm.Status.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for i, event := range sm.Events {
+		if err := event.Validate(); err != nil {
+			return fmt.Errorf("event[%d]: %w", i, err)
+		}
+	}
+
+	if sm.Links.Count < 0 || sm.Links.Count > consts.MaxLinkedTraces {
+		return fmt.Errorf("links: count must be between 0 and %d, got %d", consts.MaxLinkedTraces, sm.Links.Count)
+	}
+
+	if err := ValidateRatio("links probability", sm.Links.Probability); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (ss *SpanStatus) Validate() error {
+	if err := ValidateGenerator(ss.Generator); err != nil {
+		return fmt.Errorf("status: %w", err)
+	}
+
+	if ss.Value == "" {
+		return fmt.Errorf("status: empty value")
+	}
+
+	return nil
+}
+
+func (ss *SpanStatus) UnmarshalYAML(node *yaml.Node) error {
+	type rawSpanStatus SpanStatus
+	status := rawSpanStatus{Attribute: consts.DefaultStatusAttribute}
+	if err := node.Decode(&status); err != nil {
+		return err
+	}
+
+	*ss = SpanStatus(status)
+
+	return nil
+}
+
+func (se *SpanEvent) Validate() error {
+	if se.Name == "" {
+		return fmt.Errorf("empty event name")
+	}
+
+	if err := ValidateRatio("probability", se.Probability); err != nil {
+		return fmt.Errorf("event %q: %w", se.Name, err)
+	}
+
+	for _, level := range se.Levels {
+		if level < 0 {
+			return fmt.Errorf("event %q: invalid level %d", se.Name, level)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/neonmei/szgen/internal/consts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSpanModel_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		model   SpanModel
+		wantErr string
+	}{
+		{
+			name: "valid model",
+			model: SpanModel{
+				Status: &SpanStatus{Generator: consts.GeneratorSequence, Value: "200,500"},
+				Events: []SpanEvent{{Name: "exception", OnError: true, Levels: []int{0, 1}}},
+				Links:  SpanLinks{Count: 2, Probability: 0.5},
+				Seed:   1,
+			},
+		},
+		{
+			name:    "error ratio out of range",
+			model:   SpanModel{ErrorRatio: 2},
+			wantErr: "error_ratio",
+		},
+		{
+			name: "error ratio with status",
+			model: SpanModel{
+				ErrorRatio: 0.1,
+				Status:     &SpanStatus{Generator: consts.GeneratorConstant, Value: "200"},
+			},
+			wantErr: "mutually exclusive",
+		},
+		{
+			name:    "invalid status generator",
+			model:   SpanModel{Status: &SpanStatus{Generator: "unknown", Value: "200"}},
+			wantErr: "invalid generator",
+		},
+		{
+			name:    "empty status value",
+			model:   SpanModel{Status: &SpanStatus{Generator: consts.GeneratorConstant}},
+			wantErr: "status: empty value",
+		},
+		{
+			name:    "empty event name",
+			model:   SpanModel{Events: []SpanEvent{{Probability: 0.5}}},
+			wantErr: "event[0]: empty event name",
+		},
+		{
+			name:    "event probability out of range",
+			model:   SpanModel{Events: []SpanEvent{{Name: "retry", Probability: 1.5}}},
+			wantErr: "invalid probability",
+		},
+		{
+			name:    "negative event level",
+			model:   SpanModel{Events: []SpanEvent{{Name: "retry", Levels: []int{-1}}}},
+			wantErr: "invalid level",
+		},
+		{
+			name:    "too many links",
+			model:   SpanModel{Links: SpanLinks{Count: consts.MaxLinkedTraces + 1}},
+			wantErr: "links: count",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.model.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSpanModel_UnmarshalYAML(t *testing.T) {
+	data := `
+name: checkout
+seed: 42
+status:
+  generator: sequence
+  value: "200,200,503"
+events:
+  - name: exception
+    on_error: true
+    attributes:
+      exception.type: TimeoutError
+links:
+  count: 1
+  probability: 0.2
+`
+	var tt TraceTask
+	require.NoError(t, yaml.Unmarshal([]byte(data), &tt))
+
+	assert.Equal(t, uint64(42), tt.Seed)
+	require.NotNil(t, tt.Status)
+	assert.Equal(t, consts.GeneratorSequence, tt.Status.Generator)
+	assert.Equal(t, consts.DefaultStatusAttribute, tt.Status.Attribute)
+	require.Len(t, tt.Events, 1)
+	assert.True(t, tt.Events[0].OnError)
+	assert.Equal(t, SpanLinks{Count: 1, Probability: 0.2}, tt.Links)
+	assert.NoError(t, tt.Validate())
+}
@@ -0,0 +1,285 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/neonmei/szgen/internal/consts"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultHistogramBoundaries mirrors the OpenTelemetry SDK defaults, used when an
+// explicit bucket histogram task does not declare its own buckets.
+var defaultHistogramBoundaries = []float64{0, 5, 10, 25, 50, 75, 100, 250, 500, 750, 1000, 2500, 5000, 7500, 10000}
+
+// MetricStream identifies the resource and export settings a task overrides on top of
+// the opentelemetry configuration. Tasks sharing a stream share a MeterProvider.
+type MetricStream struct {
+	Service     string
+	Replica     int
+	Pod         int
+	Temporality string
+	Aggregation string
+}
+
+func (mc *MetricTask) Stream() MetricStream {
+	return MetricStream{
+		Service:     mc.Service,
+		Replica:     mc.Replica,
+		Pod:         mc.Pod,
+		Temporality: mc.Temporality,
+		Aggregation: mc.Aggregation,
+	}
+}
+
+// IsDefault reports whether the stream exports through the configuration as-is.
+func (s MetricStream) IsDefault() bool {
+	return s == MetricStream{}
+}
+
+// MetricStreams groups tasks by the stream they export through. Tasks using the
+// default stream are not included as they don't need a derived configuration.
+func (c *Config) MetricStreams() map[MetricStream][]MetricTask {
+	streams := make(map[MetricStream][]MetricTask)
+	if c.Metrics == nil {
+		return streams
+	}
+
+	for _, task := range c.Metrics.Tasks {
+		if stream := task.Stream(); !stream.IsDefault() {
+			streams[stream] = append(streams[stream], task)
+		}
+	}
+
+	return streams
+}
+
+// StreamOTelConfig derives an otelconf configuration for a stream. The service and replica
+// resources are layered over the configured one, temporality is set on every OTLP reader, while
+// aggregation is expressed as one view per instrument.
+// Views that already set an aggregation are dropped from an aggregation stream, as the
+// SDK would otherwise produce a duplicate stream for every instrument they match.
+func (c *Config) StreamOTelConfig(stream MetricStream, tasks []MetricTask) (map[string]any, error) {
+	otelCfg, err := cloneOTelConfig(c.OpenTelemetry)
+	if err != nil {
+		return nil, err
+	}
+
+	// streams only carry metrics, traces and logs keep using the main configuration
+	delete(otelCfg, "tracer_provider")
+	delete(otelCfg, "logger_provider")
+
+	meterProvider, ok := otelCfg["meter_provider"].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("stream %+v: no meter_provider configuration found", stream)
+	}
+
+	if stream.Service != "" {
+		service, ok := c.Service(stream.Service)
+		if !ok {
+			return nil, fmt.Errorf("stream %+v: unknown service %q", stream, stream.Service)
+		}
+
+		attrs := maps.Clone(service.Attributes)
+		if attrs == nil {
+			attrs = make(map[string]any, 1)
+		}
+		attrs["service.name"] = service.Name
+		setResourceAttributes(otelCfg, attrs)
+	}
+
+	if stream.Replica > 0 {
+		attrs, err := c.Replicas.InstanceAttributes(c.serviceName(stream.Service), stream.Replica-1)
+		if err != nil {
+			return nil, err
+		}
+		setResourceAttributes(otelCfg, attrs)
+	}
+
+	if stream.Pod > 0 && c.Cluster != nil {
+		attrs, err := c.Cluster.PodAttributes(stream.Pod - 1)
+		if err != nil {
+			return nil, err
+		}
+		setResourceAttributes(otelCfg, attrs)
+	}
+
+	if stream.Temporality != "" {
+		readers, _ := meterProvider["readers"].([]any)
+		for _, reader := range readers {
+			setReaderTemporality(reader, stream.Temporality)
+		}
+	}
+
+	if stream.Aggregation != "" {
+		views, _ := meterProvider["views"].([]any)
+		views = slices.DeleteFunc(views, hasAggregation)
+
+		// tasks sharing an instrument agree on its aggregation, see validateMetricStreams
+		seen := make(map[string]bool, len(tasks))
+		for _, task := range tasks {
+			if !seen[task.Name] {
+				seen[task.Name] = true
+				views = append(views, aggregationView(task))
+			}
+		}
+		meterProvider["views"] = views
+	}
+
+	return otelCfg, nil
+}
+
+// validateMetricStreams rejects tasks recording the same instrument of the same
+// service with different export settings.
+func validateMetricStreams(tasks []MetricTask) error {
+	type instrument struct{ service, name string }
+	seen := make(map[instrument]MetricTask, len(tasks))
+
+	for _, task := range tasks {
+		key := instrument{service: task.Service, name: task.Name}
+		prev, ok := seen[key]
+		if !ok {
+			seen[key] = task
+			continue
+		}
+
+		if prev.Temporality != task.Temporality {
+			return fmt.Errorf("metric %q: conflicting temporality %q and %q", task.Name, prev.Temporality, task.Temporality)
+		}
+
+		if prev.Aggregation != task.Aggregation {
+			return fmt.Errorf("metric %q: conflicting aggregation %q and %q", task.Name, prev.Aggregation, task.Aggregation)
+		}
+
+		if !slices.Equal(prev.Buckets, task.Buckets) {
+			return fmt.Errorf("metric %q: conflicting buckets %v and %v", task.Name, prev.Buckets, task.Buckets)
+		}
+	}
+
+	return nil
+}
+
+// validateStreamTemporality rejects tasks overriding the temporality when a configured reader
+// can't honour it, only OTLP exporters having a temporality preference.
+func validateStreamTemporality(tasks []MetricTask, otelCfg map[string]any) error {
+	meterProvider, _ := otelCfg["meter_provider"].(map[string]any)
+	readers := anySlice(meterProvider["readers"])
+
+	for _, task := range tasks {
+		if task.Temporality == "" {
+			continue
+		}
+
+		for i, reader := range readers {
+			if exporter := readerExporter(reader); exporter != "otlp_grpc" && exporter != "otlp_http" {
+				return fmt.Errorf("metric %q: temporality requires otlp_grpc or otlp_http exporters, reader[%d] exports through %s",
+					task.Name, i, exporter)
+			}
+		}
+	}
+
+	return nil
+}
+
+// readerExporter names the exporter of a reader, "pull" for pull readers.
+func readerExporter(reader any) string {
+	r, _ := reader.(map[string]any)
+	periodic, ok := r["periodic"].(map[string]any)
+	if !ok {
+		return "pull"
+	}
+
+	exporter, _ := periodic["exporter"].(map[string]any)
+	names := slices.Sorted(maps.Keys(exporter))
+	if len(names) == 0 {
+		return "no exporter"
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// anySlice accepts the readers as loaded from YAML or as built by the default configuration.
+func anySlice(value any) []any {
+	switch v := value.(type) {
+	case []any:
+		return v
+	case []map[string]any:
+		items := make([]any, 0, len(v))
+		for _, item := range v {
+			items = append(items, item)
+		}
+		return items
+	default:
+		return nil
+	}
+}
+
+func cloneOTelConfig(otelCfg map[string]any) (map[string]any, error) {
+	data, err := yaml.Marshal(otelCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal otelconf configuration: %w", err)
+	}
+
+	clone := make(map[string]any)
+	if err := yaml.Unmarshal(data, &clone); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal otelconf configuration: %w", err)
+	}
+
+	return clone, nil
+}
+
+func setReaderTemporality(reader any, temporality string) {
+	r, _ := reader.(map[string]any)
+	periodic, _ := r["periodic"].(map[string]any)
+	exporter, _ := periodic["exporter"].(map[string]any)
+
+	for _, name := range []string{"otlp_grpc", "otlp_http"} {
+		if otlp, ok := exporter[name].(map[string]any); ok {
+			otlp["temporality_preference"] = temporality
+		}
+	}
+}
+
+func hasAggregation(view any) bool {
+	v, _ := view.(map[string]any)
+	stream, _ := v["stream"].(map[string]any)
+	_, ok := stream["aggregation"]
+	return ok
+}
+
+func aggregationView(task MetricTask) map[string]any {
+	var aggregation map[string]any
+	switch task.Aggregation {
+	case consts.AggregationExplicitBucketHistogram:
+		buckets := task.Buckets
+		if len(buckets) == 0 {
+			buckets = defaultHistogramBoundaries
+		}
+		aggregation = map[string]any{
+			"explicit_bucket_histogram": map[string]any{
+				"boundaries":     buckets,
+				"record_min_max": true,
+			},
+		}
+	case consts.AggregationExponentialHistogram:
+		aggregation = map[string]any{
+			"base2_exponential_bucket_histogram": map[string]any{
+				"max_size":       consts.DefaultOTelMaxSize,
+				"max_scale":      consts.DefaultOTelMaxScale,
+				"record_min_max": true,
+			},
+		}
+	}
+
+	return map[string]any{
+		"selector": map[string]any{
+			"instrument_name": task.Name,
+			"instrument_type": consts.MetricTypeHistogram,
+		},
+		"stream": map[string]any{
+			"aggregation": aggregation,
+		},
+	}
+}
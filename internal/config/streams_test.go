@@ -0,0 +1,222 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/neonmei/szgen/internal/consts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_MetricStreams(t *testing.T) {
+	cfg := &Config{
+		Metrics: &MetricsConfig{
+			Tasks: []MetricTask{
+				{Name: "default.metric"},
+				{Name: "delta.metric", Temporality: consts.TemporalityDelta},
+				{Name: "cumulative.metric", Temporality: consts.TemporalityCumulative},
+				{Name: "other.cumulative.metric", Temporality: consts.TemporalityCumulative},
+			},
+		},
+	}
+
+	streams := cfg.MetricStreams()
+	assert.Len(t, streams, 2)
+	assert.Len(t, streams[MetricStream{Temporality: consts.TemporalityDelta}], 1)
+	assert.Len(t, streams[MetricStream{Temporality: consts.TemporalityCumulative}], 2)
+	assert.NotContains(t, streams, MetricStream{})
+}
+
+func TestConfig_StreamOTelConfig(t *testing.T) {
+	t.Run("temporality overrides otlp readers", func(t *testing.T) {
+		cfg, err := NewConfig(WithDefaultConfig("test"))
+		require.NoError(t, err)
+
+		stream := MetricStream{Temporality: consts.TemporalityCumulative}
+		otelCfg, err := cfg.StreamOTelConfig(stream, nil)
+		require.NoError(t, err)
+
+		reader := otelCfg["meter_provider"].(map[string]any)["readers"].([]any)[0].(map[string]any)
+		exporter := reader["periodic"].(map[string]any)["exporter"].(map[string]any)["otlp_grpc"].(map[string]any)
+		assert.Equal(t, consts.TemporalityCumulative, exporter["temporality_preference"])
+
+		// base configuration must be left untouched
+		baseReader := cfg.OpenTelemetry["meter_provider"].(map[string]any)["readers"].([]map[string]any)[0]
+		baseExporter := baseReader["periodic"].(map[string]any)["exporter"].(map[string]any)["otlp_grpc"].(map[string]any)
+		assert.Equal(t, consts.DefaultExportTemporality, baseExporter["temporality_preference"])
+	})
+
+	t.Run("aggregation replaces aggregating views", func(t *testing.T) {
+		cfg, err := NewConfig(WithDefaultConfig("test"))
+		require.NoError(t, err)
+
+		tasks := []MetricTask{{
+			Name:        "request.duration",
+			Kind:        consts.MetricTypeHistogram,
+			Aggregation: consts.AggregationExplicitBucketHistogram,
+			Buckets:     []float64{0.1, 0.5, 1},
+		}}
+		otelCfg, err := cfg.StreamOTelConfig(tasks[0].Stream(), tasks)
+		require.NoError(t, err)
+
+		views := otelCfg["meter_provider"].(map[string]any)["views"].([]any)
+		require.Len(t, views, 1)
+
+		view := views[0].(map[string]any)
+		assert.Equal(t, "request.duration", view["selector"].(map[string]any)["instrument_name"])
+		aggregation := view["stream"].(map[string]any)["aggregation"].(map[string]any)
+		assert.Equal(t, []float64{0.1, 0.5, 1}, aggregation["explicit_bucket_histogram"].(map[string]any)["boundaries"])
+	})
+
+	t.Run("one view per instrument", func(t *testing.T) {
+		cfg, err := NewConfig(WithDefaultConfig("test"))
+		require.NoError(t, err)
+
+		task := MetricTask{Name: "request.duration", Kind: consts.MetricTypeHistogram, Aggregation: consts.AggregationExponentialHistogram}
+		other := task
+		other.Attributes = map[string]any{"http.route": "/checkout"}
+
+		otelCfg, err := cfg.StreamOTelConfig(task.Stream(), []MetricTask{task, other})
+		require.NoError(t, err)
+		assert.Len(t, otelCfg["meter_provider"].(map[string]any)["views"].([]any), 1)
+	})
+
+	t.Run("only metrics are derived", func(t *testing.T) {
+		cfg := &Config{OpenTelemetry: map[string]any{
+			"meter_provider":  map[string]any{},
+			"tracer_provider": map[string]any{},
+			"logger_provider": map[string]any{},
+		}}
+
+		otelCfg, err := cfg.StreamOTelConfig(MetricStream{Temporality: consts.TemporalityDelta}, nil)
+		require.NoError(t, err)
+		assert.Contains(t, otelCfg, "meter_provider")
+		assert.NotContains(t, otelCfg, "tracer_provider")
+		assert.NotContains(t, otelCfg, "logger_provider")
+	})
+
+	t.Run("no meter provider", func(t *testing.T) {
+		cfg := &Config{OpenTelemetry: map[string]any{"file_format": "1.0"}}
+		_, err := cfg.StreamOTelConfig(MetricStream{Temporality: consts.TemporalityDelta}, nil)
+		assert.ErrorContains(t, err, "no meter_provider")
+	})
+}
+
+func TestValidateMetricStreams(t *testing.T) {
+	tests := []struct {
+		name    string
+		tasks   []MetricTask
+		wantErr string
+	}{
+		{
+			name: "same name same settings",
+			tasks: []MetricTask{
+				{Name: "a", Temporality: consts.TemporalityDelta},
+				{Name: "a", Temporality: consts.TemporalityDelta},
+			},
+		},
+		{
+			name: "different names",
+			tasks: []MetricTask{
+				{Name: "a", Temporality: consts.TemporalityDelta},
+				{Name: "b", Temporality: consts.TemporalityCumulative},
+			},
+		},
+		{
+			name: "same name different services",
+			tasks: []MetricTask{
+				{Name: "a", Service: "checkout", Temporality: consts.TemporalityDelta},
+				{Name: "a", Service: "payments", Temporality: consts.TemporalityCumulative},
+			},
+		},
+		{
+			name: "conflicting temporality",
+			tasks: []MetricTask{
+				{Name: "a", Temporality: consts.TemporalityDelta},
+				{Name: "a", Temporality: consts.TemporalityCumulative},
+			},
+			wantErr: "conflicting temporality",
+		},
+		{
+			name: "conflicting aggregation",
+			tasks: []MetricTask{
+				{Name: "a", Aggregation: consts.AggregationExplicitBucketHistogram},
+				{Name: "a"},
+			},
+			wantErr: "conflicting aggregation",
+		},
+		{
+			name: "conflicting buckets",
+			tasks: []MetricTask{
+				{Name: "a", Aggregation: consts.AggregationExplicitBucketHistogram, Buckets: []float64{1, 2}},
+				{Name: "a", Aggregation: consts.AggregationExplicitBucketHistogram, Buckets: []float64{1, 3}},
+			},
+			wantErr: "conflicting buckets",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMetricStreams(tt.tasks)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateStreamTemporality(t *testing.T) {
+	otlpReader := map[string]any{"periodic": map[string]any{"exporter": map[string]any{"otlp_http": map[string]any{}}}}
+	consoleReader := map[string]any{"periodic": map[string]any{"exporter": map[string]any{"console": map[string]any{}}}}
+	pullReader := map[string]any{"pull": map[string]any{}}
+
+	tests := []struct {
+		name    string
+		readers []any
+		tasks   []MetricTask
+		wantErr string
+	}{
+		{
+			name:    "otlp readers",
+			readers: []any{otlpReader},
+			tasks:   []MetricTask{{Name: "a", Temporality: consts.TemporalityCumulative}},
+		},
+		{
+			name:    "console reader without temporality",
+			readers: []any{consoleReader},
+			tasks:   []MetricTask{{Name: "a"}},
+		},
+		{
+			name:    "console reader",
+			readers: []any{otlpReader, consoleReader},
+			tasks:   []MetricTask{{Name: "a"}, {Name: "b", Temporality: consts.TemporalityDelta}},
+			wantErr: `metric "b": temporality requires otlp_grpc or otlp_http exporters, reader[1] exports through console`,
+		},
+		{
+			name:    "pull reader",
+			readers: []any{pullReader},
+			tasks:   []MetricTask{{Name: "a", Temporality: consts.TemporalityDelta}},
+			wantErr: `metric "a": temporality requires otlp_grpc or otlp_http exporters, reader[0] exports through pull`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			otelCfg := map[string]any{"meter_provider": map[string]any{"readers": tt.readers}}
+			err := validateStreamTemporality(tt.tasks, otelCfg)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("default configuration", func(t *testing.T) {
+		cfg, err := NewConfig(WithDefaultConfig("test"))
+		require.NoError(t, err)
+		assert.NoError(t, validateStreamTemporality([]MetricTask{{Name: "a", Temporality: consts.TemporalityDelta}}, cfg.OpenTelemetry))
+	})
+}
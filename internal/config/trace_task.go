@@ -0,0 +1,172 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/neonmei/szgen/internal/consts"
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	TraceTaskOption func(*TraceTask)
+
+	// TraceTask generates Count traces, one every Rate. Each trace is a span tree Depth
+	// levels deep where every span has FanOut children. Span durations in milliseconds
+	// are drawn from the value generator, and SpanKinds sets the kind of every level,
+	// the last kind being reused for deeper levels. SpanModel sets status, events and links.
+	TraceTask struct {
+		Name       string         `yaml:"name"`
+		Rate       time.Duration  `yaml:"rate,omitempty"`
+		Count      int            `yaml:"count,omitempty"`
+		Depth      int            `yaml:"depth,omitempty"`
+		FanOut     int            `yaml:"fan_out,omitempty"`
+		SpanKinds  []string       `yaml:"span_kinds,omitempty"`
+		Value      string         `yaml:"value,omitempty"`
+		Generator  string         `yaml:"generator,omitempty"`
+		Attributes map[string]any `yaml:"attributes,omitempty"`
+		SpanModel  `yaml:",inline"`
+	}
+)
+
+func NewTraceTask(options ...TraceTaskOption) *TraceTask {
+	tt := &TraceTask{
+		Name:      consts.DefaultSpanName,
+		Rate:      consts.DefaultRate,
+		Count:     consts.DefaultCount,
+		Depth:     consts.DefaultSpanDepth,
+		FanOut:    consts.DefaultSpanFanOut,
+		Value:     consts.DefaultSpanDuration,
+		Generator: consts.DefaultGenerator,
+	}
+
+	for _, option := range options {
+		option(tt)
+	}
+
+	return tt
+}
+
+// SpansPerTrace is the amount of spans in every generated trace.
+func (tt *TraceTask) SpansPerTrace() int {
+	spans, level := 0, 1
+	for range tt.Depth {
+		spans += level
+		level *= tt.FanOut
+	}
+
+	return spans
+}
+
+func (tt *TraceTask) Validate() error {
+	if tt.Name == "" {
+		return fmt.Errorf("empty span name")
+	}
+
+	if err := ValidateGenerator(tt.Generator); err != nil {
+		return fmt.Errorf("trace %q: %w", tt.Name, err)
+	}
+
+	if tt.Rate == 0 {
+		return fmt.Errorf("trace %q: empty rate", tt.Name)
+	}
+
+	if tt.Depth < 1 || tt.FanOut < 1 {
+		return fmt.Errorf("trace %q: depth and fan_out must be at least 1", tt.Name)
+	}
+
+	if spans := tt.SpansPerTrace(); spans > consts.MaxSpansPerTrace {
+		return fmt.Errorf("trace %q: %d spans per trace exceeds the maximum of %d", tt.Name, spans, consts.MaxSpansPerTrace)
+	}
+
+	for _, kind := range tt.SpanKinds {
+		if err := ValidateSpanKind(kind); err != nil {
+			return fmt.Errorf("trace %q: %w", tt.Name, err)
+		}
+	}
+
+	if err := tt.SpanModel.Validate(); err != nil {
+		return fmt.Errorf("trace %q: %w", tt.Name, err)
+	}
+
+	return nil
+}
+
+func (tt *TraceTask) UnmarshalYAML(node *yaml.Node) error {
+	defaultTask := NewTraceTask()
+
+	type rawTraceTask TraceTask
+	if err := node.Decode((*rawTraceTask)(defaultTask)); err != nil {
+		return err
+	}
+
+	*tt = *defaultTask
+
+	return nil
+}
+
+func WithSpanName(name string) TraceTaskOption {
+	return func(tt *TraceTask) {
+		tt.Name = name
+	}
+}
+
+func WithTraceRate(rate time.Duration) TraceTaskOption {
+	return func(tt *TraceTask) {
+		tt.Rate = rate
+	}
+}
+
+func WithTraceCount(count int) TraceTaskOption {
+	return func(tt *TraceTask) {
+		tt.Count = count
+	}
+}
+
+func WithDepth(depth int) TraceTaskOption {
+	return func(tt *TraceTask) {
+		tt.Depth = depth
+	}
+}
+
+func WithFanOut(fanOut int) TraceTaskOption {
+	return func(tt *TraceTask) {
+		tt.FanOut = fanOut
+	}
+}
+
+func WithSpanKinds(kinds []string) TraceTaskOption {
+	return func(tt *TraceTask) {
+		tt.SpanKinds = kinds
+	}
+}
+
+func WithTraceValue(value string) TraceTaskOption {
+	return func(tt *TraceTask) {
+		tt.Value = value
+	}
+}
+
+func WithTraceGenerator(generator string) TraceTaskOption {
+	return func(tt *TraceTask) {
+		tt.Generator = generator
+	}
+}
+
+func WithErrorRatio(ratio float64) TraceTaskOption {
+	return func(tt *TraceTask) {
+		tt.ErrorRatio = ratio
+	}
+}
+
+func WithSpanModel(model SpanModel) TraceTaskOption {
+	return func(tt *TraceTask) {
+		tt.SpanModel = model
+	}
+}
+
+func WithTraceAttributes(attrs map[string]any) TraceTaskOption {
+	return func(tt *TraceTask) {
+		tt.Attributes = attrs
+	}
+}
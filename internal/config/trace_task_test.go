@@ -0,0 +1,145 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/neonmei/szgen/internal/consts"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestNewTraceTask(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		tt := NewTraceTask()
+		assert.Equal(t, consts.DefaultSpanName, tt.Name)
+		assert.Equal(t, consts.DefaultRate, tt.Rate)
+		assert.Equal(t, consts.DefaultCount, tt.Count)
+		assert.Equal(t, consts.DefaultSpanDepth, tt.Depth)
+		assert.Equal(t, consts.DefaultSpanFanOut, tt.FanOut)
+		assert.Equal(t, consts.DefaultSpanDuration, tt.Value)
+		assert.Equal(t, consts.DefaultGenerator, tt.Generator)
+	})
+
+	t.Run("with options", func(t *testing.T) {
+		tt := NewTraceTask(
+			WithSpanName("checkout"),
+			WithTraceRate(time.Second),
+			WithTraceCount(5),
+			WithDepth(3),
+			WithFanOut(4),
+			WithSpanKinds([]string{consts.SpanKindServer, consts.SpanKindClient}),
+			WithTraceValue("10-50"),
+			WithTraceGenerator(consts.GeneratorRandom),
+			WithErrorRatio(0.1),
+			WithTraceAttributes(map[string]any{"key": "val"}),
+		)
+
+		assert.Equal(t, "checkout", tt.Name)
+		assert.Equal(t, time.Second, tt.Rate)
+		assert.Equal(t, 5, tt.Count)
+		assert.Equal(t, 3, tt.Depth)
+		assert.Equal(t, 4, tt.FanOut)
+		assert.Equal(t, []string{consts.SpanKindServer, consts.SpanKindClient}, tt.SpanKinds)
+		assert.Equal(t, "10-50", tt.Value)
+		assert.Equal(t, consts.GeneratorRandom, tt.Generator)
+		assert.Equal(t, 0.1, tt.ErrorRatio)
+		assert.Equal(t, map[string]any{"key": "val"}, tt.Attributes)
+	})
+}
+
+func TestTraceTask_SpansPerTrace(t *testing.T) {
+	tests := []struct {
+		depth, fanOut, want int
+	}{
+		{depth: 1, fanOut: 5, want: 1},
+		{depth: 2, fanOut: 2, want: 3},
+		{depth: 3, fanOut: 2, want: 7},
+		{depth: 3, fanOut: 1, want: 3},
+	}
+
+	for _, tt := range tests {
+		task := NewTraceTask(WithDepth(tt.depth), WithFanOut(tt.fanOut))
+		assert.Equal(t, tt.want, task.SpansPerTrace())
+	}
+}
+
+func TestTraceTask_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		task    *TraceTask
+		wantErr string
+	}{
+		{
+			name: "valid task",
+			task: NewTraceTask(),
+		},
+		{
+			name:    "empty name",
+			task:    NewTraceTask(WithSpanName("")),
+			wantErr: "empty span name",
+		},
+		{
+			name:    "invalid generator",
+			task:    NewTraceTask(WithTraceGenerator("unknown")),
+			wantErr: "invalid generator",
+		},
+		{
+			name:    "empty rate",
+			task:    NewTraceTask(WithTraceRate(0)),
+			wantErr: "empty rate",
+		},
+		{
+			name:    "zero depth",
+			task:    NewTraceTask(WithDepth(0)),
+			wantErr: "depth and fan_out must be at least 1",
+		},
+		{
+			name:    "too many spans",
+			task:    NewTraceTask(WithDepth(10), WithFanOut(10)),
+			wantErr: "exceeds the maximum",
+		},
+		{
+			name:    "invalid span kind",
+			task:    NewTraceTask(WithSpanKinds([]string{"gateway"})),
+			wantErr: "invalid span kind",
+		},
+		{
+			name:    "error ratio out of range",
+			task:    NewTraceTask(WithErrorRatio(1.5)),
+			wantErr: "error_ratio",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.task.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestTraceTask_UnmarshalYAML(t *testing.T) {
+	data := `
+name: checkout
+depth: 3
+span_kinds: [server, client]
+error_ratio: 0.05
+`
+	var tt TraceTask
+	err := yaml.Unmarshal([]byte(data), &tt)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "checkout", tt.Name)
+	assert.Equal(t, 3, tt.Depth)
+	assert.Equal(t, []string{consts.SpanKindServer, consts.SpanKindClient}, tt.SpanKinds)
+	assert.Equal(t, 0.05, tt.ErrorRatio)
+	assert.Equal(t, consts.DefaultSpanFanOut, tt.FanOut)
+	assert.Equal(t, consts.DefaultRate, tt.Rate)
+	assert.Equal(t, consts.DefaultSpanDuration, tt.Value)
+}
@@ -0,0 +1,5 @@
+package config
+
+type TracesConfig struct {
+	Tasks []TraceTask `yaml:"tasks"`
+}
@@ -14,14 +14,31 @@ var (
 
 	validMetricTypes   = []string{consts.MetricTypeCounter, consts.MetricTypeGauge, consts.MetricTypeHistogram, consts.MetricTypeUpDownCounter}
 	validTemporalities = []string{consts.TemporalityCumulative, consts.TemporalityDelta}
+	validAggregations  = []string{consts.AggregationExplicitBucketHistogram, consts.AggregationExponentialHistogram}
 	validGenerators    = []string{
 		consts.GeneratorConstant,
 		consts.GeneratorRandom,
 		consts.GeneratorStep,
 		consts.GeneratorSine,
 		consts.GeneratorSequence,
+		consts.GeneratorExponential,
+	}
+	validValueTypes = []string{consts.ValueTypeInt64, consts.ValueTypeFloat64}
+	validSeverities = []string{
+		consts.SeverityTrace,
+		consts.SeverityDebug,
+		consts.SeverityInfo,
+		consts.SeverityWarn,
+		consts.SeverityError,
+		consts.SeverityFatal,
+	}
+	validSpanKinds = []string{
+		consts.SpanKindInternal,
+		consts.SpanKindServer,
+		consts.SpanKindClient,
+		consts.SpanKindProducer,
+		consts.SpanKindConsumer,
 	}
-	validValueTypes      = []string{consts.ValueTypeInt64, consts.ValueTypeFloat64}
 	validInstrumentKinds = []string{
 		consts.InstrumentKindUndefined,
 		consts.InstrumentKindCounter,
@@ -67,6 +84,38 @@ func ValidateTemporality(temporality string) error {
 	return nil
 }
 
+func ValidateAggregation(aggregation string) error {
+	if !slices.Contains(validAggregations, aggregation) {
+		return fmt.Errorf("invalid aggregation '%s', must be one of: %s", aggregation, strings.Join(validAggregations, ", "))
+	}
+
+	return nil
+}
+
+func ValidateSpanKind(spanKind string) error {
+	if !slices.Contains(validSpanKinds, spanKind) {
+		return fmt.Errorf("invalid span kind '%s', must be one of: %s", spanKind, strings.Join(validSpanKinds, ", "))
+	}
+
+	return nil
+}
+
+func ValidateSeverity(severity string) error {
+	if !slices.Contains(validSeverities, severity) {
+		return fmt.Errorf("invalid severity '%s', must be one of: %s", severity, strings.Join(validSeverities, ", "))
+	}
+
+	return nil
+}
+
+func ValidateRatio(name string, ratio float64) error {
+	if ratio < 0 || ratio > 1 {
+		return fmt.Errorf("invalid %s %v, must be between 0 and 1", name, ratio)
+	}
+
+	return nil
+}
+
 func ValidateValueType(valueType string) error {
 	if !slices.Contains(validValueTypes, valueType) {
 		return fmt.Errorf("invalid value type '%s', must be one of: %s", valueType, strings.Join(validValueTypes, ", "))
@@ -89,3 +138,41 @@ func ValidateMetricName(metricName string) error {
 	}
 	return nil
 }
+
+// findCycle walks the graph from every node in order and returns the first cycle found,
+// starting and ending on the same node, or nil when the graph has none.
+func findCycle(nodes []string, edges func(node string) []string) []string {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int, len(nodes))
+
+	var visit func(node string, path []string) []string
+	visit = func(node string, path []string) []string {
+		switch state[node] {
+		case visiting:
+			return append(path[slices.Index(path, node):], node)
+		case visited:
+			return nil
+		}
+
+		state[node] = visiting
+		for _, next := range edges(node) {
+			if cycle := visit(next, append(path, node)); cycle != nil {
+				return cycle
+			}
+		}
+		state[node] = visited
+
+		return nil
+	}
+
+	for _, node := range nodes {
+		if cycle := visit(node, nil); cycle != nil {
+			return cycle
+		}
+	}
+
+	return nil
+}
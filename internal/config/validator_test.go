@@ -1,6 +1,8 @@
 package config
 
 import (
+	"maps"
+	"slices"
 	"strings"
 	"testing"
 
@@ -100,6 +102,29 @@ func TestValidateTemporality(t *testing.T) {
 	}
 }
 
+func TestValidateAggregation(t *testing.T) {
+	tests := []struct {
+		aggregation string
+		wantErr     bool
+	}{
+		{consts.AggregationExplicitBucketHistogram, false},
+		{consts.AggregationExponentialHistogram, false},
+		{"base2_exponential_bucket_histogram", true},
+		{"invalid", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.aggregation, func(t *testing.T) {
+			err := ValidateAggregation(tt.aggregation)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestValidateValueType(t *testing.T) {
 	tests := []struct {
 		valType string
@@ -148,3 +173,24 @@ func TestValidateMetricName(t *testing.T) {
 		})
 	}
 }
+
+func TestFindCycle(t *testing.T) {
+	tests := []struct {
+		name  string
+		edges map[string][]string
+		want  []string
+	}{
+		{"empty", nil, nil},
+		{"chain", map[string][]string{"a": {"b"}, "b": {"c"}}, nil},
+		{"diamond", map[string][]string{"a": {"b", "c"}, "b": {"d"}, "c": {"d"}}, nil},
+		{"self", map[string][]string{"a": {"a"}}, []string{"a", "a"}},
+		{"cycle", map[string][]string{"a": {"b"}, "b": {"c"}, "c": {"b"}}, []string{"b", "c", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes := slices.Sorted(maps.Keys(tt.edges))
+			assert.Equal(t, tt.want, findCycle(nodes, func(node string) []string { return tt.edges[node] }))
+		})
+	}
+}
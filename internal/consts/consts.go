@@ -10,7 +10,10 @@ const (
 	AggregationExponentialHistogram    = "base2_exponential_histogram"
 	ExecutorStrategySerial             = "serial"
 	ExecutorStrategyConcurrent         = "concurrent"
+	ExecutorStrategyDAG                = "dag"
+	ExecutorStrategyRamp               = "ramp"
 	GeneratorConstant                  = "constant"
+	GeneratorExponential               = "exponential"
 	GeneratorRandom                    = "random"
 	GeneratorSequence                  = "sequence"
 	GeneratorSine                      = "sine"
@@ -25,6 +28,43 @@ const (
 	ValueTypeInt64                     = "int64"
 )
 
+const (
+	FailurePolicyContinue = "continue"
+	FailurePolicyFailFast = "fail_fast"
+	FailurePolicyRetry    = "retry"
+)
+
+const (
+	SpanKindClient   = "client"
+	SpanKindConsumer = "consumer"
+	SpanKindInternal = "internal"
+	SpanKindProducer = "producer"
+	SpanKindServer   = "server"
+)
+
+const (
+	LatencyFast   = "fast"
+	LatencyNormal = "normal"
+	LatencySlow   = "slow"
+)
+
+const (
+	PresetDBClient   = "db-client"
+	PresetHTTPServer = "http-server"
+	PresetK8sPod     = "k8s-pod"
+	PresetMessaging  = "messaging"
+	PresetSystem     = "system"
+)
+
+const (
+	SeverityDebug = "debug"
+	SeverityError = "error"
+	SeverityFatal = "fatal"
+	SeverityInfo  = "info"
+	SeverityTrace = "trace"
+	SeverityWarn  = "warn"
+)
+
 const (
 	InstrumentKindUndefined     = ""
 	InstrumentKindCounter       = "counter"
@@ -34,25 +74,56 @@ const (
 )
 
 const (
+	DefaultBackfillInterval  = time.Minute
+	DefaultClusterCPU        = "0.05,0.5"
+	DefaultClusterMemory     = "67108864,268435456"
+	DefaultClusterName       = "szgen-cluster"
 	DefaultConfigFile        = "szgen.yaml"
 	DefaultCount             = 1
 	DefaultDelta             = 1.0
 	DefaultDescription       = "Metric generated with szgen"
 	DefaultExecutorStrategy  = ExecutorStrategySerial
 	DefaultExportTemporality = TemporalityDelta
+	DefaultFailurePolicy     = FailurePolicyFailFast
 	DefaultGenerator         = GeneratorConstant
+	DefaultLatencyProfile    = LatencyNormal
+	DefaultLogBody           = "Log record {{ .Index }} generated with szgen"
+	DefaultLogName           = "szgen.log"
+	DefaultLoggerName        = "szgen"
 	DefaultMeterName         = "szgen"
 	DefaultMetricKind        = MetricTypeCounter
 	DefaultMetricName        = "szgen.metric"
 	DefaultOTLPEndpoint      = "http://127.0.0.1:4317"
 	DefaultOTLPInsecure      = true
 	DefaultOTLPInterval      = time.Second
+	DefaultPresetCount       = 600
 	DefaultRate              = time.Second
+	DefaultReplicaHostName   = "{{ .Service }}-host-{{ .Index }}"
+	DefaultReplicaInstanceID = "{{ .Service }}-{{ .Index }}"
+	DefaultRetryAttempts     = 3
+	DefaultRetryBackoff      = time.Second
+	DefaultServiceName       = "szgen"
+	DefaultSeverity          = SeverityInfo
+	DefaultScenarioBody      = "Handled {{ .Name }} in {{ .Latency }}ms"
+	DefaultScenarioMetric    = "szgen.request.duration"
+	DefaultScenarioName      = "szgen.request"
+	DefaultScenarioUnit      = "ms"
+	DefaultSpanDepth         = 2
+	DefaultSpanDuration      = "100"
+	DefaultSpanFanOut        = 2
+	DefaultSpanName          = "szgen.operation"
+	DefaultStatusAttribute   = "http.response.status_code"
+	DefaultTracerName        = "szgen"
 	DefaultSineGeneratorB    = 10
 	DefaultValue             = "1"
 	DefaultValueType         = ValueTypeFloat64
 
 	DefaultFlushTimeout = 5 * time.Second
+	MaxRetryBackoff     = 5 * time.Minute
+
+	DefaultProgressRefresh  = 500 * time.Millisecond
+	DefaultProgressInterval = 10 * time.Second
+	MaxProgressTasks        = 20
 
 	DefaultOTelIntervalMillis = 1000
 	DefaultOTelTimeoutMillis  = 1000
@@ -61,7 +132,23 @@ const (
 
 	DefaultFilePerm = 0o644
 
-	ParamMaxConcurrency = "max_concurrency"
+	ParamMaxConcurrency     = "max_concurrency"
+	ParamMaxPointsPerSecond = "max_points_per_second"
+	ParamRampStart          = "start"
+	ParamRampEnd            = "end"
+	ParamRampUp             = "ramp_up"
+	ParamRampHold           = "hold"
+	ParamRampDown           = "ramp_down"
+
+	RepeatInfinite = "infinite"
+
+	ShardIndexAttribute = "szgen.shard.index"
+
+	MaxSpansPerTrace = 10000
+	MaxLinkedTraces  = 128
+
+	StatusCodeClientError = 400
+	StatusCodeServerError = 500
 
 	SineParamIndexB      = 1
 	SineParamIndexVShift = 2
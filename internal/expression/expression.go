@@ -0,0 +1,291 @@
+// Package expression evaluates arithmetic expressions over the value of a task, e.g.
+// "value * 0.02" or "max(value - 10, 0)".
+//
+// Expressions support numbers, the value variable, the + - * / % ^ operators with the
+// usual precedence, parentheses and the functions listed in functions.
+package expression
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Variable is the name expressions use to refer to the source value.
+const Variable = "value"
+
+type (
+	// Expression is a parsed expression, safe for concurrent use.
+	Expression struct {
+		source string
+		eval   node
+	}
+
+	node func(value float64) float64
+
+	function struct {
+		args int
+		fn   func(args ...float64) float64
+	}
+
+	parser struct {
+		src string
+		pos int
+	}
+)
+
+var functions = map[string]function{
+	"abs":   {args: 1, fn: func(a ...float64) float64 { return math.Abs(a[0]) }},
+	"ceil":  {args: 1, fn: func(a ...float64) float64 { return math.Ceil(a[0]) }},
+	"exp":   {args: 1, fn: func(a ...float64) float64 { return math.Exp(a[0]) }},
+	"floor": {args: 1, fn: func(a ...float64) float64 { return math.Floor(a[0]) }},
+	"log":   {args: 1, fn: func(a ...float64) float64 { return math.Log(a[0]) }},
+	"max":   {args: 2, fn: func(a ...float64) float64 { return math.Max(a[0], a[1]) }},
+	"min":   {args: 2, fn: func(a ...float64) float64 { return math.Min(a[0], a[1]) }},
+	"round": {args: 1, fn: func(a ...float64) float64 { return math.Round(a[0]) }},
+	"sqrt":  {args: 1, fn: func(a ...float64) float64 { return math.Sqrt(a[0]) }},
+}
+
+// Parse compiles an expression.
+func Parse(src string) (*Expression, error) {
+	p := &parser{src: src}
+
+	p.skipSpaces()
+	if p.done() {
+		return nil, fmt.Errorf("empty expression")
+	}
+
+	eval, err := p.parseSum()
+	if err != nil {
+		return nil, fmt.Errorf("expression %q: %w", src, err)
+	}
+
+	if p.skipSpaces(); !p.done() {
+		return nil, fmt.Errorf("expression %q: unexpected %q at position %d", src, p.src[p.pos:], p.pos)
+	}
+
+	return &Expression{source: src, eval: eval}, nil
+}
+
+// Eval computes the expression for a source value.
+func (e *Expression) Eval(value float64) float64 {
+	return e.eval(value)
+}
+
+func (e *Expression) String() string {
+	return e.source
+}
+
+func (p *parser) parseSum() (node, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		switch p.peek() {
+		case '+':
+			p.pos++
+			right, err := p.parseProduct()
+			if err != nil {
+				return nil, err
+			}
+			l := left
+			left = func(v float64) float64 { return l(v) + right(v) }
+		case '-':
+			p.pos++
+			right, err := p.parseProduct()
+			if err != nil {
+				return nil, err
+			}
+			l := left
+			left = func(v float64) float64 { return l(v) - right(v) }
+		default:
+			return left, nil
+		}
+	}
+}
+
+func (p *parser) parseProduct() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op := p.peek()
+		if op != '*' && op != '/' && op != '%' {
+			return left, nil
+		}
+		p.pos++
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		l := left
+		switch op {
+		case '*':
+			left = func(v float64) float64 { return l(v) * right(v) }
+		case '/':
+			left = func(v float64) float64 { return l(v) / right(v) }
+		case '%':
+			left = func(v float64) float64 { return math.Mod(l(v), right(v)) }
+		}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	switch p.peek() {
+	case '-':
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(v float64) float64 { return -operand(v) }, nil
+	case '+':
+		p.pos++
+		return p.parseUnary()
+	}
+
+	return p.parsePower()
+}
+
+// parsePower binds tighter than unary minus on its left and is right associative.
+func (p *parser) parsePower() (node, error) {
+	base, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek() != '^' {
+		return base, nil
+	}
+	p.pos++
+
+	exponent, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	return func(v float64) float64 { return math.Pow(base(v), exponent(v)) }, nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	c := p.peek()
+	switch {
+	case c == '(':
+		p.pos++
+		inner, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ')' {
+			return nil, fmt.Errorf("missing closing parenthesis at position %d", p.pos)
+		}
+		p.pos++
+		return inner, nil
+
+	case c == '.' || unicode.IsDigit(rune(c)):
+		return p.parseNumber()
+
+	case unicode.IsLetter(rune(c)) || c == '_':
+		return p.parseIdentifier()
+
+	case c == 0:
+		return nil, fmt.Errorf("unexpected end of expression")
+
+	default:
+		return nil, fmt.Errorf("unexpected %q at position %d", c, p.pos)
+	}
+}
+
+func (p *parser) parseNumber() (node, error) {
+	start := p.pos
+	for !p.done() && (unicode.IsDigit(rune(p.src[p.pos])) || p.src[p.pos] == '.') {
+		p.pos++
+	}
+
+	number, err := strconv.ParseFloat(p.src[start:p.pos], 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid number %q", p.src[start:p.pos])
+	}
+
+	return func(float64) float64 { return number }, nil
+}
+
+func (p *parser) parseIdentifier() (node, error) {
+	start := p.pos
+	for !p.done() && (unicode.IsLetter(rune(p.src[p.pos])) || unicode.IsDigit(rune(p.src[p.pos])) || p.src[p.pos] == '_') {
+		p.pos++
+	}
+	name := p.src[start:p.pos]
+
+	if p.peek() != '(' {
+		if name != Variable {
+			return nil, fmt.Errorf("unknown variable %q, only %q is available", name, Variable)
+		}
+		return func(v float64) float64 { return v }, nil
+	}
+	p.pos++
+
+	fn, ok := functions[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %q, must be one of: %s", name, strings.Join(slices.Sorted(maps.Keys(functions)), ", "))
+	}
+
+	var args []node
+	for p.peek() != ')' {
+		if len(args) > 0 {
+			if p.peek() != ',' {
+				return nil, fmt.Errorf("expected ',' at position %d", p.pos)
+			}
+			p.pos++
+		}
+
+		arg, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	p.pos++
+
+	if len(args) != fn.args {
+		return nil, fmt.Errorf("function %q takes %d arguments, got %d", name, fn.args, len(args))
+	}
+
+	return func(v float64) float64 {
+		values := make([]float64, len(args))
+		for i, arg := range args {
+			values[i] = arg(v)
+		}
+		return fn.fn(values...)
+	}, nil
+}
+
+// peek returns the next non space character without consuming it, 0 at the end.
+func (p *parser) peek() byte {
+	p.skipSpaces()
+	if p.done() {
+		return 0
+	}
+
+	return p.src[p.pos]
+}
+
+func (p *parser) skipSpaces() {
+	for !p.done() && unicode.IsSpace(rune(p.src[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.src)
+}
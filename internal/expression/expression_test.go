@@ -0,0 +1,73 @@
+package expression
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		value    float64
+		expected float64
+	}{
+		{name: "variable", expr: "value", value: 7, expected: 7},
+		{name: "constant", expr: "42", value: 7, expected: 42},
+		{name: "ratio", expr: "value * 0.02", value: 500, expected: 10},
+		{name: "precedence", expr: "1 + value * 2", value: 3, expected: 7},
+		{name: "parentheses", expr: "(1 + value) * 2", value: 3, expected: 8},
+		{name: "left associative", expr: "value - 2 - 3", value: 10, expected: 5},
+		{name: "modulo", expr: "value % 4", value: 10, expected: 2},
+		{name: "unary minus", expr: "-value + 1", value: 3, expected: -2},
+		{name: "power", expr: "2 ^ 3 ^ 2", expected: 512},
+		{name: "power binds tighter than unary minus", expr: "-value ^ 2", value: 3, expected: -9},
+		{name: "functions", expr: "max(value - 10, 0) + round(2.6)", value: 4, expected: 3},
+		{name: "nested functions", expr: "sqrt(abs(value))", value: -16, expected: 4},
+		{name: "spaces", expr: "  value*  1024 ", value: 2, expected: 2048},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := Parse(tt.expr)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.expected, expr.Eval(tt.value), 1e-9)
+			assert.Equal(t, tt.expr, expr.String())
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		expr   string
+		errMsg string
+	}{
+		{name: "empty", expr: " ", errMsg: "empty expression"},
+		{name: "unknown variable", expr: "requests * 2", errMsg: `unknown variable "requests"`},
+		{name: "unknown function", expr: "avg(value)", errMsg: `unknown function "avg"`},
+		{name: "wrong arity", expr: "max(value)", errMsg: `function "max" takes 2 arguments, got 1`},
+		{name: "missing parenthesis", expr: "(value + 1", errMsg: "missing closing parenthesis"},
+		{name: "dangling operator", expr: "value +", errMsg: "unexpected end of expression"},
+		{name: "trailing input", expr: "value 2", errMsg: "unexpected"},
+		{name: "invalid number", expr: "1.2.3", errMsg: "invalid number"},
+		{name: "unexpected character", expr: "value & 1", errMsg: "unexpected"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.expr)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestExpression_Eval_DivisionByZero(t *testing.T) {
+	expr, err := Parse("1 / value")
+	require.NoError(t, err)
+	assert.True(t, math.IsInf(expr.Eval(0), 1))
+}
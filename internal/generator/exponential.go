@@ -0,0 +1,43 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// newExponentialGenerator draws exponentially distributed values with the given mean,
+// e.g. the time between arrivals of a Poisson process.
+func newExponentialGenerator[T int64 | float64](ctx context.Context, valueStr string, count int, o options) (ValueGenerator[T], error) {
+	mean, err := parseValue[float64](valueStr)
+	if err != nil {
+		return nil, err
+	}
+
+	if mean <= 0 {
+		return nil, fmt.Errorf("mean %v must be positive", mean)
+	}
+
+	return func(yield func(T) bool) {
+		for range count {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				value := o.expFloat64() * mean
+
+				var v T
+				switch any(v).(type) {
+				case int64:
+					v = T(math.Round(value))
+				case float64:
+					v = T(value)
+				}
+
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}, nil
+}
@@ -0,0 +1,45 @@
+package generator
+
+import (
+	"context"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewExponentialGenerator(t *testing.T) {
+	t.Run("mean", func(t *testing.T) {
+		gen, err := newExponentialGenerator[float64](context.Background(), "2", 10000, options{rand: rand.New(rand.NewPCG(1, 2))})
+		require.NoError(t, err)
+
+		var sum float64
+		for v := range gen {
+			require.GreaterOrEqual(t, v, 0.0)
+			sum += v
+		}
+
+		assert.InDelta(t, 2.0, sum/10000, 0.1)
+	})
+
+	t.Run("int64 values are rounded", func(t *testing.T) {
+		gen, err := newExponentialGenerator[int64](context.Background(), "100", 100, options{})
+		require.NoError(t, err)
+
+		count := 0
+		for v := range gen {
+			assert.GreaterOrEqual(t, v, int64(0))
+			count++
+		}
+		assert.Equal(t, 100, count)
+	})
+
+	t.Run("invalid mean", func(t *testing.T) {
+		_, err := newExponentialGenerator[float64](context.Background(), "0", 5, options{})
+		assert.Error(t, err)
+
+		_, err = newExponentialGenerator[float64](context.Background(), "fast", 5, options{})
+		assert.Error(t, err)
+	})
+}
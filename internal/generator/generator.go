@@ -10,16 +10,18 @@ import (
 
 type ValueGenerator[T ~int64 | ~float64] iter.Seq[T]
 
-func New[T int64 | float64](ctx context.Context, pattern string, value string, count int) (ValueGenerator[T], error) {
+func New[T int64 | float64](ctx context.Context, pattern string, value string, count int, opts ...Option) (ValueGenerator[T], error) {
 	switch pattern {
 	case consts.GeneratorConstant:
 		return newConstantGenerator[T](ctx, value, count)
 	case consts.GeneratorRandom:
-		return newRandomGenerator[T](ctx, value, count)
+		return newRandomGenerator[T](ctx, value, count, newOptions(opts...))
 	case consts.GeneratorStep:
 		return newStepGenerator[T](ctx, value, count)
 	case consts.GeneratorSine:
 		return newSineGenerator[T](ctx, value, count)
+	case consts.GeneratorExponential:
+		return newExponentialGenerator[T](ctx, value, count, newOptions(opts...))
 	case consts.GeneratorSequence:
 		return newSequenceGenerator[T](ctx, value, count)
 	default:
@@ -0,0 +1,51 @@
+package generator
+
+import "math/rand/v2"
+
+type (
+	Option  func(*options)
+	options struct {
+		rand *rand.Rand
+	}
+)
+
+func newOptions(opts ...Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// WithRand draws random values from a specific source, making them reproducible
+// when the source is seeded.
+func WithRand(r *rand.Rand) Option {
+	return func(o *options) {
+		o.rand = r
+	}
+}
+
+func (o options) int64N(n int64) int64 {
+	if o.rand != nil {
+		return o.rand.Int64N(n)
+	}
+
+	return rand.Int64N(n)
+}
+
+func (o options) expFloat64() float64 {
+	if o.rand != nil {
+		return o.rand.ExpFloat64()
+	}
+
+	return rand.ExpFloat64()
+}
+
+func (o options) float64() float64 {
+	if o.rand != nil {
+		return o.rand.Float64()
+	}
+
+	return rand.Float64()
+}
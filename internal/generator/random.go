@@ -3,10 +3,9 @@ package generator
 import (
 	"context"
 	"fmt"
-	"math/rand/v2"
 )
 
-func newRandomGenerator[T int64 | float64](ctx context.Context, valueStr string, count int) (ValueGenerator[T], error) {
+func newRandomGenerator[T int64 | float64](ctx context.Context, valueStr string, count int, o options) (ValueGenerator[T], error) {
 	values, err := parseRange[T](valueStr)
 	if err != nil {
 		return nil, err
@@ -33,11 +32,11 @@ func newRandomGenerator[T int64 | float64](ctx context.Context, valueStr string,
 				switch any(value).(type) {
 				case int64:
 					diff := int64(maxVal) - int64(minVal)
-					val := int64(minVal) + rand.Int64N(diff+1)
+					val := int64(minVal) + o.int64N(diff+1)
 					value = T(val)
 				case float64:
 					diff := float64(maxVal) - float64(minVal)
-					val := float64(minVal) + rand.Float64()*diff
+					val := float64(minVal) + o.float64()*diff
 					value = T(val)
 				}
 
@@ -2,6 +2,9 @@ package generator
 
 import (
 	"context"
+	"iter"
+	"math/rand/v2"
+	"slices"
 	"testing"
 	"time"
 
@@ -56,7 +59,7 @@ func TestRandomGenerator(t *testing.T) {
 }
 
 func genHelper[T int64 | float64](t *testing.T, ctx context.Context, valueStr string, count int, min, max float64, wantErr bool) {
-	gen, err := newRandomGenerator[T](ctx, valueStr, count)
+	gen, err := newRandomGenerator[T](ctx, valueStr, count, options{})
 	if wantErr {
 		assert.Error(t, err)
 		return
@@ -69,3 +72,14 @@ func genHelper[T int64 | float64](t *testing.T, ctx context.Context, valueStr st
 		assert.LessOrEqual(t, val, max, "value %v > max %v", val, max)
 	}
 }
+
+func TestRandomGenerator_Seeded(t *testing.T) {
+	draw := func(seed uint64) []float64 {
+		gen, err := New[float64](context.Background(), "random", "0,100", 20, WithRand(rand.New(rand.NewPCG(seed, 0))))
+		require.NoError(t, err)
+		return slices.Collect(iter.Seq[float64](gen))
+	}
+
+	assert.Equal(t, draw(42), draw(42))
+	assert.NotEqual(t, draw(42), draw(43))
+}
@@ -0,0 +1,84 @@
+// Package idgen generates trace and span IDs. IDs are drawn from the random source
+// carried by the context when there is one, so seeded tasks produce reproducible traces.
+package idgen
+
+import (
+	"context"
+	"encoding/binary"
+	"math/rand/v2"
+	"sync"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type ctxKey struct{}
+
+// ContextWithRand makes spans started with ctx draw their IDs from r. The source is
+// not safe for concurrent use, so it must only be shared by spans started from one goroutine.
+func ContextWithRand(ctx context.Context, r *rand.Rand) context.Context {
+	return context.WithValue(ctx, ctxKey{}, r)
+}
+
+// Generator implements the SDK IDGenerator, falling back to a shared source for
+// spans started without a source in their context.
+type Generator struct {
+	mu       sync.Mutex
+	fallback *rand.Rand
+}
+
+func New() *Generator {
+	return &Generator{
+		fallback: rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
+	}
+}
+
+func (g *Generator) NewIDs(ctx context.Context) (trace.TraceID, trace.SpanID) {
+	var traceID trace.TraceID
+	var spanID trace.SpanID
+
+	g.draw(ctx, func(r *rand.Rand) {
+		traceID = newTraceID(r)
+		spanID = newSpanID(r)
+	})
+
+	return traceID, spanID
+}
+
+func (g *Generator) NewSpanID(ctx context.Context, _ trace.TraceID) trace.SpanID {
+	var spanID trace.SpanID
+	g.draw(ctx, func(r *rand.Rand) {
+		spanID = newSpanID(r)
+	})
+
+	return spanID
+}
+
+func (g *Generator) draw(ctx context.Context, fn func(*rand.Rand)) {
+	if r, ok := ctx.Value(ctxKey{}).(*rand.Rand); ok && r != nil {
+		fn(r)
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	fn(g.fallback)
+}
+
+func newTraceID(r *rand.Rand) trace.TraceID {
+	var id trace.TraceID
+	for !id.IsValid() {
+		binary.BigEndian.PutUint64(id[:8], r.Uint64())
+		binary.BigEndian.PutUint64(id[8:], r.Uint64())
+	}
+
+	return id
+}
+
+func newSpanID(r *rand.Rand) trace.SpanID {
+	var id trace.SpanID
+	for !id.IsValid() {
+		binary.BigEndian.PutUint64(id[:], r.Uint64())
+	}
+
+	return id
+}
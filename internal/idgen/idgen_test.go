@@ -0,0 +1,39 @@
+package idgen
+
+import (
+	"context"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerator(t *testing.T) {
+	t.Run("seeded context is reproducible", func(t *testing.T) {
+		g := New()
+
+		draw := func() (any, any, any) {
+			ctx := ContextWithRand(context.Background(), rand.New(rand.NewPCG(7, 0)))
+			traceID, spanID := g.NewIDs(ctx)
+			return traceID, spanID, g.NewSpanID(ctx, traceID)
+		}
+
+		t1, s1, c1 := draw()
+		t2, s2, c2 := draw()
+		assert.Equal(t, t1, t2)
+		assert.Equal(t, s1, s2)
+		assert.Equal(t, c1, c2)
+		assert.NotEqual(t, s1, c1)
+	})
+
+	t.Run("fallback without source", func(t *testing.T) {
+		g := New()
+
+		traceID, spanID := g.NewIDs(context.Background())
+		assert.True(t, traceID.IsValid())
+		assert.True(t, spanID.IsValid())
+
+		other, _ := g.NewIDs(context.Background())
+		assert.NotEqual(t, traceID, other)
+	})
+}
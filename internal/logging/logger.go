@@ -1,12 +1,18 @@
 package logging
 
 import (
+	"io"
 	"log/slog"
 	"os"
 )
 
+// level and format are the ones the logger was started with, kept to redirect it.
+var (
+	level  = slog.LevelInfo
+	format string
+)
+
 func StartLogger(logLevel, logFormat string) {
-	level := slog.LevelInfo
 	switch logLevel {
 	case "debug":
 		level = slog.LevelDebug
@@ -17,15 +23,28 @@ func StartLogger(logLevel, logFormat string) {
 	case "error":
 		level = slog.LevelError
 	}
+	format = logFormat
+
+	slog.SetDefault(newLogger(os.Stderr))
+}
+
+// Redirect writes the logs to w until restore is called, e.g. so they scroll above the
+// progress displayed on the terminal.
+func Redirect(w io.Writer) (restore func()) {
+	previous := slog.Default()
+	slog.SetDefault(newLogger(w))
+
+	return func() { slog.SetDefault(previous) }
+}
 
+func newLogger(w io.Writer) *slog.Logger {
 	var handler slog.Handler
-	switch logFormat {
+	switch format {
 	case "json":
-		handler = slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: level})
+		handler = slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level})
 	default:
-		handler = slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})
+		handler = slog.NewTextHandler(w, &slog.HandlerOptions{Level: level})
 	}
 
-	logger := slog.New(handler)
-	slog.SetDefault(logger)
+	return slog.New(handler)
 }
@@ -0,0 +1,242 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/neonmei/szgen/internal/config"
+	"go.opentelemetry.io/contrib/otelconf"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// Backfill records metrics over a virtual time range. Every stream records through a
+// MeterProvider with manual readers instead of periodic ones, collected at the end of
+// every window and exported right away with the timestamps of the window rather than
+// the current time: cumulative points start at the beginning of the range and delta
+// points at the beginning of their window.
+type Backfill struct {
+	from    time.Time
+	main    *backfillStream
+	streams map[config.MetricStream]*backfillStream
+}
+
+type backfillStream struct {
+	sdk     otelconf.SDK
+	readers []backfillReader
+}
+
+type backfillReader struct {
+	reader   *sdkmetric.ManualReader
+	exporter sdkmetric.Exporter
+}
+
+// NewBackfill builds the providers of every metric stream of the configuration, with
+// their range starting at from.
+func NewBackfill(ctx context.Context, cfg *config.Config, from time.Time) (*Backfill, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("config cannot be nil")
+	}
+
+	conf, err := parseOTelConfig(cfg.OpenTelemetry)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Backfill{from: from, streams: make(map[config.MetricStream]*backfillStream)}
+	if b.main, err = newBackfillStream(ctx, conf); err != nil {
+		return nil, err
+	}
+
+	for stream, tasks := range cfg.MetricStreams() {
+		otelCfg, err := cfg.StreamOTelConfig(stream, tasks)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("failed to derive opentelemetry config: %w", err), b.Shutdown(ctx))
+		}
+
+		conf, err := parseOTelConfig(otelCfg)
+		if err != nil {
+			return nil, errors.Join(err, b.Shutdown(ctx))
+		}
+
+		if b.streams[stream], err = newBackfillStream(ctx, conf); err != nil {
+			return nil, errors.Join(fmt.Errorf("stream %+v: %w", stream, err), b.Shutdown(ctx))
+		}
+	}
+
+	return b, nil
+}
+
+// newBackfillStream starts an SDK exporting metrics only, its periodic readers are
+// replaced by manual readers sharing their exporter and temporality.
+func newBackfillStream(ctx context.Context, cfg *otelconf.OpenTelemetryConfiguration) (*backfillStream, error) {
+	pushReaders, err := newPushReaders(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	conf := *withoutReaders(cfg)
+	conf.TracerProvider = nil
+	conf.LoggerProvider = nil
+
+	stream := &backfillStream{}
+	opts := make([]sdkmetric.Option, 0, len(pushReaders))
+	for _, r := range pushReaders {
+		reader := sdkmetric.NewManualReader(
+			sdkmetric.WithTemporalitySelector(r.exporter.Temporality),
+			sdkmetric.WithAggregationSelector(r.exporter.Aggregation),
+		)
+		stream.readers = append(stream.readers, backfillReader{reader: reader, exporter: r.exporter})
+		opts = append(opts, sdkmetric.WithReader(reader))
+	}
+
+	stream.sdk, err = otelconf.NewSDK(
+		otelconf.WithOpenTelemetryConfiguration(conf),
+		otelconf.WithMeterProviderOptions(opts...),
+	)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to create otel sdk: %w", err), stream.shutdownExporters(ctx))
+	}
+
+	return stream, nil
+}
+
+// MeterProvider returns the provider a metric task should record through.
+func (b *Backfill) MeterProvider(task config.MetricTask) metric.MeterProvider {
+	if stream, ok := b.streams[task.Stream()]; ok {
+		return stream.sdk.MeterProvider()
+	}
+
+	return b.main.sdk.MeterProvider()
+}
+
+// Export collects what was recorded during the window ending at end and exports it.
+func (b *Backfill) Export(ctx context.Context, start, end time.Time) error {
+	err := b.main.export(ctx, b.from, start, end)
+	for stream, s := range b.streams {
+		if sErr := s.export(ctx, b.from, start, end); sErr != nil {
+			err = errors.Join(err, fmt.Errorf("stream %+v: %w", stream, sErr))
+		}
+	}
+
+	return err
+}
+
+func (b *Backfill) Shutdown(ctx context.Context) error {
+	var err error
+	if b.main != nil {
+		err = b.main.shutdown(ctx)
+	}
+
+	for _, s := range b.streams {
+		err = errors.Join(err, s.shutdown(ctx))
+	}
+
+	return err
+}
+
+func (s *backfillStream) export(ctx context.Context, from, start, end time.Time) error {
+	var err error
+	for _, r := range s.readers {
+		var rm metricdata.ResourceMetrics
+		if cErr := r.reader.Collect(ctx, &rm); cErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to collect metrics: %w", cErr))
+			continue
+		}
+
+		if len(rm.ScopeMetrics) == 0 {
+			continue
+		}
+
+		retime(&rm, from, start, end)
+		if eErr := r.exporter.Export(ctx, &rm); eErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to export metrics: %w", eErr))
+		}
+	}
+
+	return err
+}
+
+func (s *backfillStream) shutdown(ctx context.Context) error {
+	// the manual readers don't own their exporters, these are shut down separately
+	err := s.sdk.Shutdown(ctx)
+	return errors.Join(err, s.shutdownExporters(ctx))
+}
+
+func (s *backfillStream) shutdownExporters(ctx context.Context) error {
+	var err error
+	for _, r := range s.readers {
+		err = errors.Join(err, r.exporter.Shutdown(ctx))
+	}
+
+	return err
+}
+
+// retime moves collected data points to the virtual window they were recorded in.
+// Cumulative points start with the range and delta ones with the window, gauges take
+// the window as well as they only hold the last value recorded.
+func retime(rm *metricdata.ResourceMetrics, from, start, end time.Time) {
+	startOf := func(temporality metricdata.Temporality) time.Time {
+		if temporality == metricdata.CumulativeTemporality {
+			return from
+		}
+		return start
+	}
+
+	for i := range rm.ScopeMetrics {
+		for j := range rm.ScopeMetrics[i].Metrics {
+			switch data := rm.ScopeMetrics[i].Metrics[j].Data.(type) {
+			case metricdata.Sum[int64]:
+				retimePoints(data.DataPoints, startOf(data.Temporality), end)
+			case metricdata.Sum[float64]:
+				retimePoints(data.DataPoints, startOf(data.Temporality), end)
+			case metricdata.Gauge[int64]:
+				retimePoints(data.DataPoints, start, end)
+			case metricdata.Gauge[float64]:
+				retimePoints(data.DataPoints, start, end)
+			case metricdata.Histogram[int64]:
+				retimeHistogramPoints(data.DataPoints, startOf(data.Temporality), end)
+			case metricdata.Histogram[float64]:
+				retimeHistogramPoints(data.DataPoints, startOf(data.Temporality), end)
+			case metricdata.ExponentialHistogram[int64]:
+				retimeExponentialPoints(data.DataPoints, startOf(data.Temporality), end)
+			case metricdata.ExponentialHistogram[float64]:
+				retimeExponentialPoints(data.DataPoints, startOf(data.Temporality), end)
+			default:
+				slog.Warn("Unexpected metric data, exporting it with its original timestamps",
+					"metric", rm.ScopeMetrics[i].Metrics[j].Name)
+			}
+		}
+	}
+}
+
+func retimePoints[N int64 | float64](points []metricdata.DataPoint[N], start, end time.Time) {
+	for i := range points {
+		points[i].StartTime, points[i].Time = start, end
+		retimeExemplars(points[i].Exemplars, end)
+	}
+}
+
+func retimeHistogramPoints[N int64 | float64](points []metricdata.HistogramDataPoint[N], start, end time.Time) {
+	for i := range points {
+		points[i].StartTime, points[i].Time = start, end
+		retimeExemplars(points[i].Exemplars, end)
+	}
+}
+
+func retimeExponentialPoints[N int64 | float64](points []metricdata.ExponentialHistogramDataPoint[N], start, end time.Time) {
+	for i := range points {
+		points[i].StartTime, points[i].Time = start, end
+		retimeExemplars(points[i].Exemplars, end)
+	}
+}
+
+func retimeExemplars[N int64 | float64](exemplars []metricdata.Exemplar[N], end time.Time) {
+	for i := range exemplars {
+		exemplars[i].Time = end
+	}
+}
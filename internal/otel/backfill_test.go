@@ -0,0 +1,54 @@
+package otel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestRetime(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	start := from.Add(time.Hour)
+	end := start.Add(time.Minute)
+	now := time.Now()
+
+	rm := metricdata.ResourceMetrics{
+		ScopeMetrics: []metricdata.ScopeMetrics{{
+			Metrics: []metricdata.Metrics{
+				{Name: "cumulative", Data: metricdata.Sum[int64]{
+					Temporality: metricdata.CumulativeTemporality,
+					DataPoints:  []metricdata.DataPoint[int64]{{StartTime: now, Time: now, Value: 1}},
+				}},
+				{Name: "delta", Data: metricdata.Histogram[float64]{
+					Temporality: metricdata.DeltaTemporality,
+					DataPoints: []metricdata.HistogramDataPoint[float64]{{
+						StartTime: now,
+						Time:      now,
+						Exemplars: []metricdata.Exemplar[float64]{{Time: now}},
+					}},
+				}},
+				{Name: "gauge", Data: metricdata.Gauge[float64]{
+					DataPoints: []metricdata.DataPoint[float64]{{StartTime: now, Time: now}},
+				}},
+			},
+		}},
+	}
+
+	retime(&rm, from, start, end)
+	metrics := rm.ScopeMetrics[0].Metrics
+
+	sum := metrics[0].Data.(metricdata.Sum[int64]).DataPoints[0]
+	assert.Equal(t, from, sum.StartTime, "cumulative points start with the range")
+	assert.Equal(t, end, sum.Time)
+
+	histogram := metrics[1].Data.(metricdata.Histogram[float64]).DataPoints[0]
+	assert.Equal(t, start, histogram.StartTime, "delta points start with the window")
+	assert.Equal(t, end, histogram.Time)
+	assert.Equal(t, end, histogram.Exemplars[0].Time)
+
+	gauge := metrics[2].Data.(metricdata.Gauge[float64]).DataPoints[0]
+	assert.Equal(t, start, gauge.StartTime)
+	assert.Equal(t, end, gauge.Time)
+}
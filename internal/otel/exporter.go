@@ -0,0 +1,193 @@
+package otel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/contrib/otelconf"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// pushReader is a periodic reader configuration with its exporter already built,
+// so the exporter can be reused outside of an otelconf managed MeterProvider.
+type pushReader struct {
+	exporter sdkmetric.Exporter
+	opts     []sdkmetric.PeriodicReaderOption
+}
+
+// newPushReaders builds the exporters of every periodic reader in the configuration,
+// mirroring what otelconf does for the subset of settings szgen supports.
+func newPushReaders(ctx context.Context, cfg *otelconf.OpenTelemetryConfiguration) ([]pushReader, error) {
+	mp, ok := cfg.MeterProvider.(*otelconf.MeterProviderJson)
+	if !ok || mp == nil {
+		return nil, fmt.Errorf("no meter_provider configuration found")
+	}
+
+	readers := make([]pushReader, 0, len(mp.Readers))
+	for i, r := range mp.Readers {
+		if r.Periodic == nil {
+			return nil, fmt.Errorf("reader[%d]: only periodic readers are supported", i)
+		}
+
+		var opts []sdkmetric.PeriodicReaderOption
+		if r.Periodic.Interval != nil {
+			opts = append(opts, sdkmetric.WithInterval(time.Duration(*r.Periodic.Interval)*time.Millisecond))
+		}
+		if r.Periodic.Timeout != nil {
+			opts = append(opts, sdkmetric.WithTimeout(time.Duration(*r.Periodic.Timeout)*time.Millisecond))
+		}
+
+		exp, err := newMetricExporter(ctx, r.Periodic.Exporter)
+		if err != nil {
+			return nil, fmt.Errorf("reader[%d]: %w", i, err)
+		}
+
+		readers = append(readers, pushReader{exporter: exp, opts: opts})
+	}
+
+	return readers, nil
+}
+
+func newMetricExporter(ctx context.Context, exporter otelconf.PushMetricExporter) (sdkmetric.Exporter, error) {
+	switch {
+	case exporter.OTLPGrpc != nil:
+		return newOTLPGrpcExporter(ctx, exporter.OTLPGrpc)
+	case exporter.OTLPHttp != nil:
+		return newOTLPHttpExporter(ctx, exporter.OTLPHttp)
+	case exporter.Console != nil:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return stdoutmetric.New(stdoutmetric.WithEncoder(enc))
+	default:
+		return nil, fmt.Errorf("unsupported metric exporter, must be one of: otlp_grpc, otlp_http, console")
+	}
+}
+
+func newOTLPGrpcExporter(ctx context.Context, cfg *otelconf.OTLPGrpcMetricExporter) (sdkmetric.Exporter, error) {
+	if cfg.CertificateFile != nil || cfg.ClientCertificateFile != nil || cfg.ClientKeyFile != nil {
+		return nil, fmt.Errorf("otlp_grpc: tls certificate files are not supported")
+	}
+
+	var opts []otlpmetricgrpc.Option
+	if cfg.Endpoint != nil {
+		u, err := url.ParseRequestURI(*cfg.Endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("otlp_grpc: invalid endpoint: %w", err)
+		}
+
+		if u.Host != "" {
+			opts = append(opts, otlpmetricgrpc.WithEndpoint(u.Host))
+		} else {
+			opts = append(opts, otlpmetricgrpc.WithEndpoint(*cfg.Endpoint))
+		}
+		if u.Scheme == "http" || (u.Scheme != "https" && cfg.Insecure != nil && *cfg.Insecure) {
+			opts = append(opts, otlpmetricgrpc.WithInsecure())
+		}
+	}
+
+	if cfg.Compression != nil && *cfg.Compression == "gzip" {
+		opts = append(opts, otlpmetricgrpc.WithCompressor(*cfg.Compression))
+	}
+	if cfg.Timeout != nil && *cfg.Timeout > 0 {
+		opts = append(opts, otlpmetricgrpc.WithTimeout(time.Duration(*cfg.Timeout)*time.Millisecond))
+	}
+	if headers := headersMap(cfg.Headers); len(headers) > 0 {
+		opts = append(opts, otlpmetricgrpc.WithHeaders(headers))
+	}
+	if cfg.TemporalityPreference != nil {
+		selector, err := temporalitySelector(*cfg.TemporalityPreference)
+		if err != nil {
+			return nil, fmt.Errorf("otlp_grpc: %w", err)
+		}
+		opts = append(opts, otlpmetricgrpc.WithTemporalitySelector(selector))
+	}
+
+	return otlpmetricgrpc.New(ctx, opts...)
+}
+
+func newOTLPHttpExporter(ctx context.Context, cfg *otelconf.OTLPHttpMetricExporter) (sdkmetric.Exporter, error) {
+	if cfg.CertificateFile != nil || cfg.ClientCertificateFile != nil || cfg.ClientKeyFile != nil {
+		return nil, fmt.Errorf("otlp_http: tls certificate files are not supported")
+	}
+
+	var opts []otlpmetrichttp.Option
+	if cfg.Endpoint != nil {
+		u, err := url.ParseRequestURI(*cfg.Endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("otlp_http: invalid endpoint: %w", err)
+		}
+
+		opts = append(opts, otlpmetrichttp.WithEndpoint(u.Host))
+		if u.Scheme == "http" {
+			opts = append(opts, otlpmetrichttp.WithInsecure())
+		}
+		if u.Path != "" {
+			opts = append(opts, otlpmetrichttp.WithURLPath(u.Path))
+		}
+	}
+
+	if cfg.Compression != nil && *cfg.Compression == "gzip" {
+		opts = append(opts, otlpmetrichttp.WithCompression(otlpmetrichttp.GzipCompression))
+	}
+	if cfg.Timeout != nil && *cfg.Timeout > 0 {
+		opts = append(opts, otlpmetrichttp.WithTimeout(time.Duration(*cfg.Timeout)*time.Millisecond))
+	}
+	if headers := headersMap(cfg.Headers); len(headers) > 0 {
+		opts = append(opts, otlpmetrichttp.WithHeaders(headers))
+	}
+	if cfg.TemporalityPreference != nil {
+		selector, err := temporalitySelector(*cfg.TemporalityPreference)
+		if err != nil {
+			return nil, fmt.Errorf("otlp_http: %w", err)
+		}
+		opts = append(opts, otlpmetrichttp.WithTemporalitySelector(selector))
+	}
+
+	return otlpmetrichttp.New(ctx, opts...)
+}
+
+func headersMap(headers []otelconf.NameStringValuePair) map[string]string {
+	result := make(map[string]string, len(headers))
+	for _, h := range headers {
+		if h.Value != nil {
+			result[h.Name] = *h.Value
+		}
+	}
+
+	return result
+}
+
+func temporalitySelector(preference otelconf.ExporterTemporalityPreference) (sdkmetric.TemporalitySelector, error) {
+	switch preference {
+	case otelconf.ExporterTemporalityPreferenceCumulative:
+		return sdkmetric.DefaultTemporalitySelector, nil
+	case otelconf.ExporterTemporalityPreferenceDelta:
+		return func(ik sdkmetric.InstrumentKind) metricdata.Temporality {
+			switch ik {
+			case sdkmetric.InstrumentKindCounter, sdkmetric.InstrumentKindHistogram, sdkmetric.InstrumentKindObservableCounter:
+				return metricdata.DeltaTemporality
+			default:
+				return metricdata.CumulativeTemporality
+			}
+		}, nil
+	case otelconf.ExporterTemporalityPreferenceLowMemory:
+		return func(ik sdkmetric.InstrumentKind) metricdata.Temporality {
+			switch ik {
+			case sdkmetric.InstrumentKindCounter, sdkmetric.InstrumentKindHistogram:
+				return metricdata.DeltaTemporality
+			default:
+				return metricdata.CumulativeTemporality
+			}
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported temporality preference %q", preference)
+	}
+}
@@ -7,15 +7,27 @@ import (
 	"log/slog"
 
 	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/idgen"
 	"go.opentelemetry.io/contrib/otelconf"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"gopkg.in/yaml.v3"
 )
 
 type SDK struct {
 	cfg *otelconf.OpenTelemetryConfiguration
 	sdk *otelconf.SDK
+
+	// streamCfgs holds a derived configuration per metric stream overriding export settings,
+	// each one is started as a separate SDK so its readers and views don't leak into others.
+	streamCfgs map[config.MetricStream]*otelconf.OpenTelemetryConfiguration
+	streamSDKs map[config.MetricStream]*otelconf.SDK
+
+	// shareExporter makes replicas and pods of a stream export through the same connections.
+	shareExporter   bool
+	sharedExporters map[config.MetricStream][]sharedPushReader
 }
 
 func NewSDK(cfg *config.Config) (*SDK, error) {
@@ -23,7 +35,33 @@ func NewSDK(cfg *config.Config) (*SDK, error) {
 		return nil, fmt.Errorf("config cannot be nil")
 	}
 
-	bytes, err := yaml.Marshal(cfg.OpenTelemetry)
+	conf, err := parseOTelConfig(cfg.OpenTelemetry)
+	if err != nil {
+		return nil, err
+	}
+
+	streamCfgs := make(map[config.MetricStream]*otelconf.OpenTelemetryConfiguration)
+	for stream, tasks := range cfg.MetricStreams() {
+		otelCfg, err := cfg.StreamOTelConfig(stream, tasks)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive opentelemetry config: %w", err)
+		}
+
+		streamCfgs[stream], err = parseOTelConfig(otelCfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &SDK{
+		cfg:           conf,
+		streamCfgs:    streamCfgs,
+		shareExporter: cfg.Replicas.ShareExporter || (cfg.Cluster != nil && cfg.Cluster.ShareExporter),
+	}, nil
+}
+
+func parseOTelConfig(otelCfg map[string]any) (*otelconf.OpenTelemetryConfiguration, error) {
+	bytes, err := yaml.Marshal(otelCfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal opentelemetry config: %w", err)
 	}
@@ -34,11 +72,14 @@ func NewSDK(cfg *config.Config) (*SDK, error) {
 		return nil, fmt.Errorf("failed to parse opentelemetry config: %w", err)
 	}
 
-	return &SDK{cfg: conf}, nil
+	return conf, nil
 }
 
 func (s *SDK) Start() error {
-	sdk, err := otelconf.NewSDK(otelconf.WithOpenTelemetryConfiguration(*s.cfg))
+	sdk, err := otelconf.NewSDK(
+		otelconf.WithOpenTelemetryConfiguration(*s.cfg),
+		otelconf.WithTracerProviderOptions(sdktrace.WithIDGenerator(idgen.New())),
+	)
 	if err != nil {
 		return fmt.Errorf("failed to create otel sdk: %w", err)
 	}
@@ -47,14 +88,65 @@ func (s *SDK) Start() error {
 	otel.SetTracerProvider(sdk.TracerProvider())
 	otel.SetMeterProvider(sdk.MeterProvider())
 	global.SetLoggerProvider(sdk.LoggerProvider())
+
+	s.streamSDKs = make(map[config.MetricStream]*otelconf.SDK, len(s.streamCfgs))
+	s.sharedExporters = make(map[config.MetricStream][]sharedPushReader)
+	for stream, cfg := range s.streamCfgs {
+		var opts []otelconf.ConfigurationOption
+		if s.shareExporter && (stream.Replica > 0 || stream.Pod > 0) {
+			readers, err := s.sharedReaders(stream, cfg)
+			if err != nil {
+				return fmt.Errorf("failed to create shared exporter for stream %+v: %w", stream, err)
+			}
+
+			cfg = withoutReaders(cfg)
+			opts = append(opts, otelconf.WithMeterProviderOptions(readers...))
+		}
+
+		opts = append(opts, otelconf.WithOpenTelemetryConfiguration(*cfg))
+		streamSDK, err := otelconf.NewSDK(opts...)
+		if err != nil {
+			return fmt.Errorf("failed to create otel sdk for stream %+v: %w", stream, err)
+		}
+		s.streamSDKs[stream] = &streamSDK
+
+		slog.Debug("Started metric stream SDK",
+			"service", stream.Service,
+			"replica", stream.Replica,
+			"temporality", stream.Temporality,
+			"aggregation", stream.Aggregation,
+		)
+	}
+
 	return nil
 }
 
+// SetErrorHandler hands the errors of every SDK, e.g. failed exports, to handle instead
+// of logging them.
+func SetErrorHandler(handle func(error)) {
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(handle))
+}
+
+// MeterProvider returns the provider a metric task should record through.
+func (s *SDK) MeterProvider(task config.MetricTask) metric.MeterProvider {
+	if streamSDK, ok := s.streamSDKs[task.Stream()]; ok {
+		return streamSDK.MeterProvider()
+	}
+
+	return otel.GetMeterProvider()
+}
+
 func (s *SDK) Shutdown(ctx context.Context) error {
+	var err error
 	if s.sdk != nil {
-		return s.sdk.Shutdown(ctx)
+		err = s.sdk.Shutdown(ctx)
 	}
-	return nil
+
+	for _, streamSDK := range s.streamSDKs {
+		err = errors.Join(err, streamSDK.Shutdown(ctx))
+	}
+
+	return err
 }
 
 func (s *SDK) ForceFlush(ctx context.Context) error {
@@ -62,14 +154,23 @@ func (s *SDK) ForceFlush(ctx context.Context) error {
 		return nil
 	}
 
+	err := forceFlush(ctx, s.sdk)
+	for _, streamSDK := range s.streamSDKs {
+		err = errors.Join(err, forceFlush(ctx, streamSDK))
+	}
+
+	return err
+}
+
+func forceFlush(ctx context.Context, sdk *otelconf.SDK) error {
 	var err error
-	if p, ok := s.sdk.MeterProvider().(interface{ ForceFlush(context.Context) error }); ok {
+	if p, ok := sdk.MeterProvider().(interface{ ForceFlush(context.Context) error }); ok {
 		err = errors.Join(err, p.ForceFlush(ctx))
 	}
-	if p, ok := s.sdk.TracerProvider().(interface{ ForceFlush(context.Context) error }); ok {
+	if p, ok := sdk.TracerProvider().(interface{ ForceFlush(context.Context) error }); ok {
 		err = errors.Join(err, p.ForceFlush(ctx))
 	}
-	if p, ok := s.sdk.LoggerProvider().(interface{ ForceFlush(context.Context) error }); ok {
+	if p, ok := sdk.LoggerProvider().(interface{ ForceFlush(context.Context) error }); ok {
 		err = errors.Join(err, p.ForceFlush(ctx))
 	}
 
@@ -0,0 +1,98 @@
+package otel
+
+import (
+	"context"
+	"sync"
+
+	"github.com/neonmei/szgen/internal/config"
+	"go.opentelemetry.io/contrib/otelconf"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// sharedExporter lets several MeterProviders export through a single connection.
+// Exports are serialized since the SDK never calls an exporter concurrently, and
+// the wrapped exporter is only shut down once every provider released it.
+type sharedExporter struct {
+	sdkmetric.Exporter
+	mu   sync.Mutex
+	refs int
+}
+
+func newSharedExporter(exp sdkmetric.Exporter) *sharedExporter {
+	return &sharedExporter{Exporter: exp}
+}
+
+// acquire registers one more provider exporting through this exporter.
+func (e *sharedExporter) acquire() *sharedExporter {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.refs++
+	return e
+}
+
+func (e *sharedExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.Exporter.Export(ctx, rm)
+}
+
+func (e *sharedExporter) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.refs--
+	if e.refs > 0 {
+		return nil
+	}
+
+	return e.Exporter.Shutdown(ctx)
+}
+
+type sharedPushReader struct {
+	exporter *sharedExporter
+	opts     []sdkmetric.PeriodicReaderOption
+}
+
+// sharedReaders returns periodic readers over the exporters shared by every replica
+// or pod of a stream, building the exporters on first use.
+func (s *SDK) sharedReaders(stream config.MetricStream, cfg *otelconf.OpenTelemetryConfiguration) ([]sdkmetric.Option, error) {
+	key := stream
+	key.Replica = 0
+	key.Pod = 0
+
+	shared, ok := s.sharedExporters[key]
+	if !ok {
+		readers, err := newPushReaders(context.Background(), cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range readers {
+			shared = append(shared, sharedPushReader{exporter: newSharedExporter(r.exporter), opts: r.opts})
+		}
+		s.sharedExporters[key] = shared
+	}
+
+	opts := make([]sdkmetric.Option, 0, len(shared))
+	for _, r := range shared {
+		opts = append(opts, sdkmetric.WithReader(sdkmetric.NewPeriodicReader(r.exporter.acquire(), r.opts...)))
+	}
+
+	return opts, nil
+}
+
+// withoutReaders copies a configuration dropping its metric readers, as these are
+// provided by szgen instead.
+func withoutReaders(cfg *otelconf.OpenTelemetryConfiguration) *otelconf.OpenTelemetryConfiguration {
+	conf := *cfg
+	if mp, ok := conf.MeterProvider.(*otelconf.MeterProviderJson); ok {
+		mpConf := *mp
+		mpConf.Readers = nil
+		conf.MeterProvider = &mpConf
+	}
+
+	return &conf
+}
@@ -0,0 +1,45 @@
+package otel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+type countingExporter struct {
+	sdkmetric.Exporter
+	exports   int
+	shutdowns int
+}
+
+func (e *countingExporter) Export(context.Context, *metricdata.ResourceMetrics) error {
+	e.exports++
+	return nil
+}
+
+func (e *countingExporter) Shutdown(context.Context) error {
+	e.shutdowns++
+	return nil
+}
+
+func TestSharedExporter(t *testing.T) {
+	exp := &countingExporter{}
+	shared := newSharedExporter(exp)
+
+	first := shared.acquire()
+	second := shared.acquire()
+
+	require.NoError(t, first.Export(context.Background(), &metricdata.ResourceMetrics{}))
+	require.NoError(t, second.Export(context.Background(), &metricdata.ResourceMetrics{}))
+	assert.Equal(t, 2, exp.exports)
+
+	require.NoError(t, first.Shutdown(context.Background()))
+	assert.Zero(t, exp.shutdowns, "exporter still in use by another provider")
+
+	require.NoError(t, second.Shutdown(context.Background()))
+	assert.Equal(t, 1, exp.shutdowns)
+}
@@ -0,0 +1,202 @@
+// Package presets builds szgen configurations emitting telemetry that follows the
+// OpenTelemetry semantic conventions, so they can be run as-is or expanded into YAML.
+package presets
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/consts"
+)
+
+type (
+	Option  func(*options)
+	options struct {
+		service    string
+		rate       time.Duration
+		count      int
+		errorRatio float64
+		latency    string
+	}
+
+	// preset describes the tasks of a preset and the resource attributes identifying
+	// the simulated entity, if any.
+	preset struct {
+		description string
+		tasks       func(o options) []config.MetricTask
+		resource    func(o options) map[string]any
+	}
+)
+
+var registry = map[string]preset{
+	consts.PresetHTTPServer: {description: "HTTP server request duration, body sizes and active requests", tasks: httpServer},
+	consts.PresetDBClient:   {description: "Database client operation duration and connection pool usage", tasks: dbClient},
+	consts.PresetMessaging:  {description: "Messaging producer and consumer throughput and durations", tasks: messaging},
+	consts.PresetSystem:     {description: "Host CPU, memory, disk and network usage", tasks: system},
+	consts.PresetK8sPod:     {description: "Kubernetes pod CPU, memory, network and uptime", tasks: k8sPod, resource: k8sPodResource},
+}
+
+// latencyProfiles are the operation durations, in seconds, of every latency profile.
+var latencyProfiles = map[string]string{
+	consts.LatencyFast:   "0.001,0.05",
+	consts.LatencyNormal: "0.01,0.3",
+	consts.LatencySlow:   "0.2,2.5",
+}
+
+func newOptions(opts ...Option) options {
+	o := options{
+		rate:    consts.DefaultRate,
+		count:   consts.DefaultPresetCount,
+		latency: consts.DefaultLatencyProfile,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// WithService runs the preset tasks as a service of their own.
+func WithService(service string) Option {
+	return func(o *options) {
+		o.service = service
+	}
+}
+
+// WithRate sets the interval between requests, or between samples for resource presets.
+func WithRate(rate time.Duration) Option {
+	return func(o *options) {
+		o.rate = rate
+	}
+}
+
+// WithCount sets the amount of requests, or samples for resource presets.
+func WithCount(count int) Option {
+	return func(o *options) {
+		o.count = count
+	}
+}
+
+// WithErrorRatio sets the ratio of failed requests, ignored by resource presets.
+func WithErrorRatio(ratio float64) Option {
+	return func(o *options) {
+		o.errorRatio = ratio
+	}
+}
+
+// WithLatency sets the latency profile (fast, normal, slow), ignored by resource presets.
+func WithLatency(profile string) Option {
+	return func(o *options) {
+		o.latency = profile
+	}
+}
+
+// Names returns the available presets.
+func Names() []string {
+	return slices.Sorted(maps.Keys(registry))
+}
+
+// Description returns a short summary of what a preset emits.
+func Description(name string) string {
+	return registry[name].description
+}
+
+// New builds the configuration of a preset. It holds tasks, services and executor
+// settings only, the OpenTelemetry configuration is left to the caller.
+func New(name string, opts ...Option) (*config.Config, error) {
+	p, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown preset %q, must be one of: %v", name, Names())
+	}
+
+	o := newOptions(opts...)
+	if err := o.validate(); err != nil {
+		return nil, fmt.Errorf("preset %q: %w", name, err)
+	}
+
+	tasks := p.tasks(o)
+
+	var services []config.ServiceConfig
+	if o.service != "" || p.resource != nil {
+		service := config.ServiceConfig{Name: o.service}
+		if service.Name == "" {
+			service.Name = consts.DefaultServiceName
+		}
+		if p.resource != nil {
+			service.Attributes = p.resource(o)
+		}
+		services = append(services, service)
+
+		for i := range tasks {
+			tasks[i].Service = service.Name
+		}
+	}
+
+	return config.NewConfig(
+		config.WithMetricsConfig(&config.MetricsConfig{Tasks: tasks}),
+		config.WithServices(services),
+		config.WithExecutorConfig(config.NewExecutorConfig(config.WithExecutorStrategy(consts.ExecutorStrategyConcurrent))),
+	)
+}
+
+func (o options) validate() error {
+	if o.rate <= 0 {
+		return fmt.Errorf("rate must be positive, got %s", o.rate)
+	}
+
+	if o.count < 1 {
+		return fmt.Errorf("count must be at least 1, got %d", o.count)
+	}
+
+	if err := config.ValidateRatio("error ratio", o.errorRatio); err != nil {
+		return err
+	}
+
+	if _, ok := latencyProfiles[o.latency]; !ok {
+		return fmt.Errorf("unknown latency profile %q, must be one of: %v", o.latency, slices.Sorted(maps.Keys(latencyProfiles)))
+	}
+
+	return nil
+}
+
+// outcomes splits a task recording one data point per request into a successful and a
+// failed series following the error ratio. Both series span the same time window, the
+// failed one carrying the extra attributes.
+func outcomes(o options, task config.MetricTask, failure map[string]any) []config.MetricTask {
+	failed := int(float64(o.count)*o.errorRatio + 0.5)
+	if o.errorRatio == 0 || failed == 0 {
+		return []config.MetricTask{task}
+	}
+
+	window := o.rate * time.Duration(o.count)
+
+	success := task
+	success.Count = o.count - failed
+	success.Rate = config.Rate((window / time.Duration(max(success.Count, 1))).Round(time.Millisecond))
+
+	failure = merge(task.Attributes, failure)
+	errors := task
+	errors.Attributes = failure
+	errors.Count = failed
+	errors.Rate = config.Rate((window / time.Duration(failed)).Round(time.Millisecond))
+
+	if success.Count == 0 {
+		return []config.MetricTask{errors}
+	}
+
+	return []config.MetricTask{success, errors}
+}
+
+func merge(base, extra map[string]any) map[string]any {
+	attrs := maps.Clone(base)
+	if attrs == nil {
+		attrs = make(map[string]any, len(extra))
+	}
+	maps.Copy(attrs, extra)
+
+	return attrs
+}
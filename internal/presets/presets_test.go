@@ -0,0 +1,176 @@
+package presets
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/consts"
+	"github.com/neonmei/szgen/internal/runner/clock"
+	"github.com/neonmei/szgen/internal/runner/metrictask"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+func TestNew(t *testing.T) {
+	for _, name := range Names() {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := New(name, WithErrorRatio(0.1), WithLatency(consts.LatencySlow))
+			require.NoError(t, err)
+			require.NotEmpty(t, cfg.MetricTasks())
+			assert.Equal(t, consts.ExecutorStrategyConcurrent, cfg.Executor.Strategy)
+			assert.NotEmpty(t, Description(name))
+
+			require.NoError(t, cfg.Executor.Validate())
+			for _, task := range cfg.MetricTasks() {
+				require.NoError(t, task.Validate(), task.Name)
+			}
+		})
+	}
+}
+
+func TestNew_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		preset string
+		opts   []Option
+		errMsg string
+	}{
+		{name: "unknown preset", preset: "mainframe", errMsg: `unknown preset "mainframe"`},
+		{name: "unknown latency", preset: consts.PresetHTTPServer, opts: []Option{WithLatency("glacial")}, errMsg: `unknown latency profile "glacial"`},
+		{name: "invalid error ratio", preset: consts.PresetHTTPServer, opts: []Option{WithErrorRatio(1.5)}, errMsg: "invalid error ratio"},
+		{name: "zero count", preset: consts.PresetSystem, opts: []Option{WithCount(0)}, errMsg: "count must be at least 1"},
+		{name: "zero rate", preset: consts.PresetSystem, opts: []Option{WithRate(0)}, errMsg: "rate must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.preset, tt.opts...)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestNew_Service(t *testing.T) {
+	t.Run("request presets run under the configured service", func(t *testing.T) {
+		cfg, err := New(consts.PresetHTTPServer, WithService("checkout"))
+		require.NoError(t, err)
+		require.Len(t, cfg.Services, 1)
+		assert.Equal(t, "checkout", cfg.Services[0].Name)
+		for _, task := range cfg.MetricTasks() {
+			assert.Equal(t, "checkout", task.Service)
+		}
+	})
+
+	t.Run("request presets use the default service otherwise", func(t *testing.T) {
+		cfg, err := New(consts.PresetHTTPServer)
+		require.NoError(t, err)
+		assert.Empty(t, cfg.Services)
+	})
+
+	t.Run("k8s-pod describes the pod in the resource", func(t *testing.T) {
+		cfg, err := New(consts.PresetK8sPod)
+		require.NoError(t, err)
+		require.Len(t, cfg.Services, 1)
+		assert.Equal(t, consts.DefaultServiceName, cfg.Services[0].Name)
+		assert.Contains(t, cfg.Services[0].Attributes, "k8s.pod.name")
+	})
+}
+
+func TestOutcomes(t *testing.T) {
+	task := config.MetricTask{Name: "requests", Attributes: map[string]any{"route": "/"}}
+
+	t.Run("no errors keeps a single series", func(t *testing.T) {
+		o := newOptions(WithCount(100))
+		assert.Equal(t, []config.MetricTask{task}, outcomes(o, task, map[string]any{"error.type": "500"}))
+	})
+
+	t.Run("errors split the series over the same window", func(t *testing.T) {
+		o := newOptions(WithCount(100), WithRate(time.Second), WithErrorRatio(0.2))
+		tasks := outcomes(o, task, map[string]any{"error.type": "500"})
+		require.Len(t, tasks, 2)
+
+		assert.Equal(t, 80, tasks[0].Count)
+		assert.Equal(t, config.Rate(1250*time.Millisecond), tasks[0].Rate)
+		assert.NotContains(t, tasks[0].Attributes, "error.type")
+
+		assert.Equal(t, 20, tasks[1].Count)
+		assert.Equal(t, config.Rate(5*time.Second), tasks[1].Rate)
+		assert.Equal(t, map[string]any{"route": "/", "error.type": "500"}, tasks[1].Attributes)
+	})
+
+	t.Run("all errors keeps the failed series only", func(t *testing.T) {
+		o := newOptions(WithCount(10), WithErrorRatio(1))
+		tasks := outcomes(o, task, map[string]any{"error.type": "500"})
+		require.Len(t, tasks, 1)
+		assert.Equal(t, 10, tasks[0].Count)
+	})
+}
+
+// levels tracks the sum of every updowncounter recorded through it.
+type levels struct {
+	noop.MeterProvider
+	counters []*level
+}
+
+type levelMeter struct {
+	noop.Meter
+	levels *levels
+}
+
+type level struct {
+	noop.Int64UpDownCounter
+	name          string
+	sum, min, max int64
+}
+
+func (l *levels) Meter(string, ...metric.MeterOption) metric.Meter {
+	return levelMeter{levels: l}
+}
+
+func (m levelMeter) Int64UpDownCounter(name string, _ ...metric.Int64UpDownCounterOption) (metric.Int64UpDownCounter, error) {
+	counter := &level{name: name}
+	m.levels.counters = append(m.levels.counters, counter)
+	return counter, nil
+}
+
+func (l *level) Add(_ context.Context, incr int64, _ ...metric.AddOption) {
+	l.sum += incr
+	l.min = min(l.min, l.sum)
+	l.max = max(l.max, l.sum)
+}
+
+func TestNew_UpDownCountersStayPositive(t *testing.T) {
+	for _, name := range Names() {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := New(name, WithCount(5000))
+			require.NoError(t, err)
+
+			for _, taskCfg := range cfg.MetricTasks() {
+				if taskCfg.Kind != consts.MetricTypeUpDownCounter {
+					continue
+				}
+
+				mp := &levels{}
+				task, err := metrictask.New(context.Background(), taskCfg, metrictask.WithMeterProvider(mp))
+				require.NoError(t, err)
+				require.NoError(t, task.Execute(clock.With(context.Background(), clock.NewInstant(time.Now()))))
+
+				_, upper, _ := strings.Cut(taskCfg.Value, ",")
+				top, err := strconv.ParseInt(upper, 10, 64)
+				require.NoError(t, err)
+
+				require.Len(t, mp.counters, 1)
+				counter := mp.counters[0]
+				assert.GreaterOrEqual(t, counter.min, int64(0), "%s went negative", counter.name)
+				assert.LessOrEqual(t, counter.max, top, "%s left its range", counter.name)
+			}
+		})
+	}
+}
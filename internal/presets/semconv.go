@@ -0,0 +1,209 @@
+package presets
+
+import (
+	"strconv"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/consts"
+)
+
+func newTask(o options, name, kind, valueType, unit, description, generator, value string, attrs map[string]any) config.MetricTask {
+	return config.MetricTask{
+		Name:        name,
+		Kind:        kind,
+		Type:        valueType,
+		Rate:        config.Rate(o.rate),
+		Count:       o.count,
+		Value:       value,
+		Attributes:  attrs,
+		Generator:   generator,
+		Description: description,
+		Unit:        unit,
+	}
+}
+
+// levelTask is an updowncounter moving between random levels within value, such as the
+// requests in flight, so its sum never leaves the range.
+func levelTask(o options, name, unit, description, value string, attrs map[string]any) config.MetricTask {
+	task := newTask(o, name, consts.MetricTypeUpDownCounter, consts.ValueTypeInt64, unit, description, consts.GeneratorRandom, value, attrs)
+	task.Level = true
+
+	return task
+}
+
+// REF: https://opentelemetry.io/docs/specs/semconv/http/http-metrics/
+func httpServer(o options) []config.MetricTask {
+	attrs := map[string]any{
+		"http.request.method":       "GET",
+		"http.route":                "/api/orders",
+		"url.scheme":                "https",
+		"network.protocol.version":  "1.1",
+		"http.response.status_code": 200,
+	}
+	failure := map[string]any{
+		"http.response.status_code": 500,
+		"error.type":                "500",
+	}
+
+	duration := newTask(o, "http.server.request.duration", consts.MetricTypeHistogram, consts.ValueTypeFloat64,
+		"s", "Duration of HTTP server requests.", consts.GeneratorRandom, latencyProfiles[o.latency], attrs)
+	requestSize := newTask(o, "http.server.request.body.size", consts.MetricTypeHistogram, consts.ValueTypeInt64,
+		"By", "Size of HTTP server request bodies.", consts.GeneratorRandom, "0,4096", attrs)
+	responseSize := newTask(o, "http.server.response.body.size", consts.MetricTypeHistogram, consts.ValueTypeInt64,
+		"By", "Size of HTTP server response bodies.", consts.GeneratorRandom, "256,65536", attrs)
+
+	tasks := outcomes(o, duration, failure)
+	tasks = append(tasks, outcomes(o, requestSize, failure)...)
+	tasks = append(tasks, outcomes(o, responseSize, failure)...)
+
+	return append(tasks, levelTask(o, "http.server.active_requests", "{request}", "Number of active HTTP server requests.",
+		"0,20", map[string]any{
+			"http.request.method": "GET",
+			"url.scheme":          "https",
+		}))
+}
+
+// REF: https://opentelemetry.io/docs/specs/semconv/database/database-metrics/
+func dbClient(o options) []config.MetricTask {
+	attrs := map[string]any{
+		"db.system.name":     "postgresql",
+		"db.namespace":       "orders",
+		"db.operation.name":  "SELECT",
+		"db.collection.name": "order_items",
+		"server.address":     "orders-db.internal",
+		"server.port":        5432,
+	}
+	failure := map[string]any{
+		"error.type":              "timeout",
+		"db.response.status_code": "57014",
+	}
+
+	duration := newTask(o, "db.client.operation.duration", consts.MetricTypeHistogram, consts.ValueTypeFloat64,
+		"s", "Duration of database client operations.", consts.GeneratorRandom, latencyProfiles[o.latency], attrs)
+	returnedRows := newTask(o, "db.client.response.returned_rows", consts.MetricTypeHistogram, consts.ValueTypeInt64,
+		"{row}", "The actual number of records returned by the database operation.", consts.GeneratorRandom, "0,500", attrs)
+
+	tasks := outcomes(o, duration, failure)
+	tasks = append(tasks, returnedRows)
+
+	for _, state := range []string{"idle", "used"} {
+		tasks = append(tasks, levelTask(o, "db.client.connection.count", "{connection}",
+			"The number of connections that are currently in state described by the state attribute.", "0,10", map[string]any{
+				"db.client.connection.pool.name": "orders-pool",
+				"db.client.connection.state":     state,
+			}))
+	}
+
+	return tasks
+}
+
+// REF: https://opentelemetry.io/docs/specs/semconv/messaging/messaging-metrics/
+func messaging(o options) []config.MetricTask {
+	destination := map[string]any{
+		"messaging.system":           "kafka",
+		"messaging.destination.name": "orders",
+		"server.address":             "kafka.internal",
+		"server.port":                9092,
+	}
+	send := merge(destination, map[string]any{"messaging.operation.name": "send", "messaging.operation.type": "send"})
+	poll := merge(destination, map[string]any{
+		"messaging.operation.name":           "poll",
+		"messaging.operation.type":           "receive",
+		"messaging.consumer.group.name":      "orders-processor",
+		"messaging.destination.partition.id": "0",
+	})
+	process := merge(poll, map[string]any{"messaging.operation.name": "process", "messaging.operation.type": "process"})
+	failure := map[string]any{"error.type": "timeout"}
+
+	tasks := outcomes(o, newTask(o, "messaging.client.sent.messages", consts.MetricTypeCounter, consts.ValueTypeInt64,
+		"{message}", "Number of messages producer attempted to send to the broker.", consts.GeneratorRandom, "1,20", send), failure)
+	tasks = append(tasks, outcomes(o, newTask(o, "messaging.client.operation.duration", consts.MetricTypeHistogram, consts.ValueTypeFloat64,
+		"s", "Duration of messaging operation initiated by a producer or consumer client.", consts.GeneratorRandom, latencyProfiles[o.latency], send), failure)...)
+	tasks = append(tasks, newTask(o, "messaging.client.consumed.messages", consts.MetricTypeCounter, consts.ValueTypeInt64,
+		"{message}", "Number of messages that were delivered to the application.", consts.GeneratorRandom, "1,20", poll))
+	tasks = append(tasks, outcomes(o, newTask(o, "messaging.process.duration", consts.MetricTypeHistogram, consts.ValueTypeFloat64,
+		"s", "Duration of processing operation.", consts.GeneratorRandom, latencyProfiles[o.latency], process), failure)...)
+
+	return tasks
+}
+
+// REF: https://opentelemetry.io/docs/specs/semconv/system/system-metrics/
+func system(o options) []config.MetricTask {
+	var tasks []config.MetricTask
+
+	cpuModes := []struct{ mode, value string }{
+		{mode: "user", value: "0.1,0.6"},
+		{mode: "system", value: "0.05,0.2"},
+		{mode: "idle", value: "0.2,0.8"},
+	}
+	for _, cpu := range cpuModes {
+		tasks = append(tasks, newTask(o, "system.cpu.utilization", consts.MetricTypeGauge, consts.ValueTypeFloat64,
+			"1", "Difference in system.cpu.time since the last measurement, divided by the elapsed time and number of logical CPUs.",
+			consts.GeneratorRandom, cpu.value, map[string]any{"cpu.mode": cpu.mode}))
+	}
+
+	for _, state := range []string{"used", "free"} {
+		tasks = append(tasks, levelTask(o, "system.memory.usage", "By", "Reports memory in use by state.",
+			"1073741824,4294967296", map[string]any{"system.memory.state": state}))
+	}
+
+	tasks = append(tasks, newTask(o, "system.memory.utilization", consts.MetricTypeGauge, consts.ValueTypeFloat64,
+		"1", "Reports memory utilization by state.", consts.GeneratorSine, "0.2,10,0.6,0", map[string]any{"system.memory.state": "used"}))
+
+	for _, direction := range []string{"read", "write"} {
+		tasks = append(tasks, newTask(o, "system.disk.io", consts.MetricTypeCounter, consts.ValueTypeInt64,
+			"By", "Disk bytes transferred.", consts.GeneratorRandom, "0,10485760", map[string]any{
+				"system.device":     "sda",
+				"disk.io.direction": direction,
+			}))
+	}
+
+	for _, direction := range []string{"receive", "transmit"} {
+		tasks = append(tasks, newTask(o, "system.network.io", consts.MetricTypeCounter, consts.ValueTypeInt64,
+			"By", "Network bytes transferred.", consts.GeneratorRandom, "0,1048576", map[string]any{
+				"network.interface.name": "eth0",
+				"network.io.direction":   direction,
+			}))
+	}
+
+	return tasks
+}
+
+// REF: https://opentelemetry.io/docs/specs/semconv/system/k8s-metrics/
+func k8sPod(o options) []config.MetricTask {
+	tasks := []config.MetricTask{
+		newTask(o, "k8s.pod.cpu.time", consts.MetricTypeCounter, consts.ValueTypeFloat64,
+			"s", "Total CPU time consumed.", consts.GeneratorRandom, "0.05,0.5", nil),
+		newTask(o, "k8s.pod.cpu.usage", consts.MetricTypeGauge, consts.ValueTypeFloat64,
+			"{cpu}", "Pod's CPU usage, measured in cpus.", consts.GeneratorRandom, "0.05,0.5", nil),
+		newTask(o, "k8s.pod.memory.usage", consts.MetricTypeGauge, consts.ValueTypeInt64,
+			"By", "Memory usage of the Pod.", consts.GeneratorSine, "33554432,10,268435456,0", nil),
+		newTask(o, "k8s.pod.uptime", consts.MetricTypeGauge, consts.ValueTypeFloat64,
+			"s", "The time the Pod has been running.", consts.GeneratorStep, "0,"+formatSeconds(o), nil),
+	}
+
+	for _, direction := range []string{"receive", "transmit"} {
+		tasks = append(tasks, newTask(o, "k8s.pod.network.io", consts.MetricTypeCounter, consts.ValueTypeInt64,
+			"By", "Network bytes for the Pod.", consts.GeneratorRandom, "0,1048576", map[string]any{
+				"network.interface.name": "eth0",
+				"network.io.direction":   direction,
+			}))
+	}
+
+	return tasks
+}
+
+func k8sPodResource(o options) map[string]any {
+	return map[string]any{
+		"k8s.namespace.name":  "default",
+		"k8s.deployment.name": "szgen",
+		"k8s.pod.name":        "szgen-5d8f7c9b4-x2x7k",
+		"k8s.pod.uid":         "5b0a6c6e-6f5e-4b5a-9c1e-7f8d2b3a4c5d",
+		"k8s.node.name":       "node-1",
+	}
+}
+
+// formatSeconds returns the sampling interval in seconds, the uptime step.
+func formatSeconds(o options) string {
+	return strconv.FormatFloat(o.rate.Seconds(), 'f', -1, 64)
+}
@@ -1,4 +1,4 @@
-package metrictask
+package runner
 
 import (
 	"fmt"
@@ -6,7 +6,8 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 )
 
-func parseAttributes(attrs map[string]any) []attribute.KeyValue {
+// ParseAttributes converts configuration attributes into OpenTelemetry attributes.
+func ParseAttributes(attrs map[string]any) []attribute.KeyValue {
 	if len(attrs) == 0 {
 		return nil
 	}
@@ -1,4 +1,4 @@
-package metrictask
+package runner
 
 import (
 	"testing"
@@ -37,7 +37,7 @@ func TestParseAttributes(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := parseAttributes(tt.input)
+			got := ParseAttributes(tt.input)
 
 			if len(tt.expected) == 0 {
 				assert.Empty(t, got)
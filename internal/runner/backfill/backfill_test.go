@@ -0,0 +1,160 @@
+package backfill
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/runner/metrictask"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// windowExporter collects the sum of the single counter recorded through reader at the
+// end of every window.
+type windowExporter struct {
+	reader  *sdkmetric.ManualReader
+	windows [][2]time.Time
+	sums    []int64
+}
+
+func (e *windowExporter) Export(ctx context.Context, start, end time.Time) error {
+	var rm metricdata.ResourceMetrics
+	if err := e.reader.Collect(ctx, &rm); err != nil {
+		return err
+	}
+
+	var sum int64
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				sum += dp.Value
+			}
+		}
+	}
+
+	e.windows = append(e.windows, [2]time.Time{start, end})
+	e.sums = append(e.sums, sum)
+	return nil
+}
+
+func newBackfiller(t *testing.T, mp *sdkmetric.MeterProvider, opts ...config.MetricTaskOption) metrictask.Backfiller {
+	t.Helper()
+
+	opts = append([]config.MetricTaskOption{
+		config.WithName("test.requests"),
+		config.WithType("int64"),
+		config.WithCount(1000),
+	}, opts...)
+
+	task, err := metrictask.New(context.Background(), *config.NewMetricTask(opts...), metrictask.WithMeterProvider(mp))
+	require.NoError(t, err)
+
+	return task.(metrictask.Backfiller)
+}
+
+func TestRun(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		r       Range
+		opts    []config.MetricTaskOption
+		windows int
+		sums    []int64
+		points  int
+	}{
+		{
+			name:    "points due at the end of a window belong to the next one",
+			r:       Range{From: from, To: from.Add(3 * time.Minute), Interval: time.Minute},
+			opts:    []config.MetricTaskOption{config.WithRate(10 * time.Second)},
+			windows: 3,
+			sums:    []int64{5, 6, 6},
+			points:  17,
+		},
+		{
+			name:    "last window is cut at the end of the range",
+			r:       Range{From: from, To: from.Add(90 * time.Second), Interval: time.Minute},
+			opts:    []config.MetricTaskOption{config.WithRate(30 * time.Second)},
+			windows: 2,
+			sums:    []int64{1, 1},
+			points:  2,
+		},
+		{
+			name:    "exhausted tasks stop recording",
+			r:       Range{From: from, To: from.Add(3 * time.Minute), Interval: time.Minute},
+			opts:    []config.MetricTaskOption{config.WithRate(20 * time.Second), config.WithCount(4)},
+			windows: 3,
+			sums:    []int64{2, 2, 0},
+			points:  4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := sdkmetric.NewManualReader(sdkmetric.WithTemporalitySelector(func(sdkmetric.InstrumentKind) metricdata.Temporality {
+				return metricdata.DeltaTemporality
+			}))
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+			exporter := &windowExporter{reader: reader}
+
+			points, err := Run(context.Background(), []metrictask.Backfiller{newBackfiller(t, mp, tt.opts...)}, exporter, tt.r)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.points, points)
+			assert.Len(t, exporter.windows, tt.windows)
+			assert.Equal(t, tt.sums, exporter.sums)
+			assert.Equal(t, tt.r.From, exporter.windows[0][0])
+			assert.Equal(t, tt.r.To, exporter.windows[len(exporter.windows)-1][1])
+		})
+	}
+}
+
+func TestRun_Cancelled(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewManualReader()))
+	_, err := Run(ctx, []metrictask.Backfiller{newBackfiller(t, mp)}, &windowExporter{}, Range{From: from, To: from.Add(time.Hour), Interval: time.Minute})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRange_Validate(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, Range{From: from, To: from.Add(time.Hour), Interval: time.Minute}.Validate())
+	assert.ErrorContains(t, Range{From: from, To: from, Interval: time.Minute}.Validate(), "must be before")
+	assert.ErrorContains(t, Range{From: from, To: from.Add(time.Hour)}.Validate(), "interval must be positive")
+}
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "2026-01-01T00:00:00Z", want: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{value: "24h", want: now.Add(-24 * time.Hour)},
+		{value: "-90m", want: now.Add(-90 * time.Minute)},
+		{value: "yesterday", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseTime(tt.value, now)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "got %s, want %s", got, tt.want)
+		})
+	}
+}
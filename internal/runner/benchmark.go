@@ -0,0 +1,169 @@
+package runner
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Benchmark measures the points every task records and how long it takes, along with
+// the errors the SDK ran into exporting them, to tell whether the collector kept up.
+// A nil Benchmark ignores errors and reports nothing.
+type Benchmark struct {
+	mu      sync.Mutex
+	tasks   []*measuredTask
+	start   time.Time
+	end     time.Time
+	errors  int
+	lastErr error
+}
+
+// TaskThroughput is the throughput achieved by a task, measured over the time it ran.
+type TaskThroughput struct {
+	Name    string
+	Points  int64
+	Elapsed time.Duration
+}
+
+type measuredTask struct {
+	Task
+	bench  *Benchmark
+	points atomic.Int64
+
+	mu      sync.Mutex
+	elapsed time.Duration
+}
+
+type pointsKey struct{}
+
+func NewBenchmark() *Benchmark {
+	return &Benchmark{}
+}
+
+// Measure wraps tasks so the points they record are counted.
+func (b *Benchmark) Measure(tasks []Task) []Task {
+	measured := make([]Task, 0, len(tasks))
+	for _, task := range tasks {
+		mt := &measuredTask{Task: task, bench: b}
+		b.tasks = append(b.tasks, mt)
+		measured = append(measured, mt)
+	}
+
+	return measured
+}
+
+// Handle counts an SDK error, it's meant to be the global OpenTelemetry error handler.
+func (b *Benchmark) Handle(err error) {
+	if b == nil || err == nil {
+		return
+	}
+
+	slog.Debug("OpenTelemetry SDK error", "error", err)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.errors++
+	b.lastErr = err
+}
+
+// Tasks returns the throughput of every measured task, in the order they were measured.
+func (b *Benchmark) Tasks() []TaskThroughput {
+	throughput := make([]TaskThroughput, 0, len(b.tasks))
+	for _, mt := range b.tasks {
+		mt.mu.Lock()
+		throughput = append(throughput, TaskThroughput{Name: mt.Name(), Points: mt.points.Load(), Elapsed: mt.elapsed})
+		mt.mu.Unlock()
+	}
+
+	return throughput
+}
+
+// Report logs the throughput of every task, then the overall one, measured from the
+// first task started to the last one finished.
+func (b *Benchmark) Report() {
+	if b == nil {
+		return
+	}
+
+	var points int64
+	for _, task := range b.Tasks() {
+		points += task.Points
+		slog.Info("Task throughput",
+			"task", task.Name,
+			"points", task.Points,
+			"points_per_second", perSecond(task.Points, task.Elapsed),
+			"elapsed", task.Elapsed.Round(time.Millisecond),
+		)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	elapsed := b.end.Sub(b.start)
+	slog.Info("Benchmark",
+		"tasks", len(b.tasks),
+		"points", points,
+		"points_per_second", perSecond(points, elapsed),
+		"elapsed", elapsed.Round(time.Millisecond),
+		"export_errors", b.errors,
+	)
+
+	if b.errors > 0 {
+		slog.Warn("The SDK failed to export some points, the collector may not have kept up",
+			"export_errors", b.errors,
+			"last_error", b.lastErr,
+		)
+	}
+}
+
+func (mt *measuredTask) Execute(ctx context.Context) error {
+	start := time.Now()
+	mt.bench.started(start)
+
+	err := mt.Task.Execute(context.WithValue(ctx, pointsKey{}, &mt.points))
+
+	end := time.Now()
+	mt.bench.finished(end)
+
+	mt.mu.Lock()
+	mt.elapsed += end.Sub(start)
+	mt.mu.Unlock()
+
+	return err
+}
+
+func (b *Benchmark) started(at time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.start.IsZero() || at.Before(b.start) {
+		b.start = at
+	}
+}
+
+func (b *Benchmark) finished(at time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if at.After(b.end) {
+		b.end = at
+	}
+}
+
+// countPoints adds n points to the task measured in the context, if any.
+func countPoints(ctx context.Context, n int) {
+	if points, ok := ctx.Value(pointsKey{}).(*atomic.Int64); ok {
+		points.Add(int64(n))
+	}
+}
+
+// perSecond formats a throughput without exponent, as benchmarks reach millions of points.
+func perSecond(points int64, elapsed time.Duration) string {
+	rate := float64(points) / max(elapsed.Seconds(), math.SmallestNonzeroFloat64)
+	return strconv.FormatFloat(rate, 'f', 2, 64)
+}
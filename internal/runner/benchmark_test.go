@@ -0,0 +1,73 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type pointsTask struct {
+	name   string
+	points int
+}
+
+func (t *pointsTask) Name() string { return t.name }
+
+func (t *pointsTask) Execute(ctx context.Context) error {
+	for range t.points {
+		if err := Throttle(ctx, 1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestBenchmark_Measure(t *testing.T) {
+	bench := NewBenchmark()
+	tasks := bench.Measure([]Task{
+		&pointsTask{name: "first", points: 100},
+		&pointsTask{name: "second", points: 50},
+	})
+
+	for _, task := range tasks {
+		require.NoError(t, task.Execute(context.Background()))
+	}
+	require.NoError(t, tasks[1].Execute(context.Background()), "repeated runs add up")
+
+	throughput := bench.Tasks()
+	require.Len(t, throughput, 2)
+	assert.Equal(t, "first", throughput[0].Name)
+	assert.Equal(t, int64(100), throughput[0].Points)
+	assert.Equal(t, "second", throughput[1].Name)
+	assert.Equal(t, int64(100), throughput[1].Points)
+	assert.Positive(t, throughput[0].Elapsed)
+
+	bench.Handle(errors.New("export failed"))
+	bench.Handle(nil)
+	assert.Equal(t, 1, bench.errors)
+
+	bench.Report()
+}
+
+func TestBenchmark_Nil(t *testing.T) {
+	var bench *Benchmark
+
+	assert.NotPanics(t, func() {
+		bench.Handle(errors.New("export failed"))
+		bench.Report()
+	})
+}
+
+func TestThrottle_CountsWithLimiter(t *testing.T) {
+	bench := NewBenchmark()
+	tasks := bench.Measure([]Task{&pointsTask{name: "limited", points: 10}})
+
+	ctx := WithLimiter(context.Background(), NewLimiter(1000))
+	require.NoError(t, tasks[0].Execute(ctx))
+
+	assert.Equal(t, int64(10), bench.Tasks()[0].Points)
+}
@@ -0,0 +1,150 @@
+// Package clock tells the time to the time-aware parts of a run: task schedules, phases,
+// executor stages and retry backoffs. A scaled clock compresses a long run, e.g. a day in
+// 24 minutes at 60x, while tests use a fake clock to go through schedules without sleeping.
+// Spans and log records are timestamped on the clock, metric timestamps are set by the SDK
+// with the wall clock.
+package clock
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+type Clock interface {
+	Now() time.Time
+
+	// After sends the time on the clock once d elapsed on it.
+	After(d time.Duration) <-chan time.Time
+}
+
+type clockKey struct{}
+
+// Real is the wall clock.
+var Real Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// scaledClock runs scale times faster than the wall clock since its origin.
+type scaledClock struct {
+	origin time.Time
+	scale  float64
+}
+
+// NewScaled returns a clock running scale times faster than the wall clock from now on,
+// the wall clock itself for a scale of 1.
+func NewScaled(scale float64) Clock {
+	if scale == 1 {
+		return Real
+	}
+
+	return &scaledClock{origin: time.Now(), scale: scale}
+}
+
+func (c *scaledClock) Now() time.Time {
+	return c.origin.Add(time.Duration(float64(time.Since(c.origin)) * c.scale))
+}
+
+func (c *scaledClock) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	time.AfterFunc(time.Duration(float64(d)/c.scale), func() { ch <- c.Now() })
+
+	return ch
+}
+
+// Fake is a clock for tests that only moves when told to. An instant one moves forward
+// right away when waited on instead, so a task goes through its whole schedule without
+// sleeping; concurrent waits add up, which suits tasks waiting one after another.
+// Safe for concurrent use.
+type Fake struct {
+	mu      sync.Mutex
+	now     time.Time
+	instant bool
+	waiters []waiter
+}
+
+type waiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+// NewFake returns a clock moving only through Advance.
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+// NewInstant returns a clock moving forward by whatever it's waited on.
+func NewInstant(now time.Time) *Fake {
+	return &Fake{now: now, instant: true}
+}
+
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.now
+}
+
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	if f.instant {
+		ch <- f.Advance(d)
+		return ch
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if d <= 0 {
+		ch <- f.now
+		return ch
+	}
+
+	f.waiters = append(f.waiters, waiter{at: f.now.Add(d), ch: ch})
+	return ch
+}
+
+// Advance moves the clock forward by d, waking up the waits that are over, and returns
+// the new time.
+func (f *Fake) Advance(d time.Duration) time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if d > 0 {
+		f.now = f.now.Add(d)
+	}
+
+	f.waiters = slices.DeleteFunc(f.waiters, func(w waiter) bool {
+		if w.at.After(f.now) {
+			return false
+		}
+
+		w.ch <- f.now
+		return true
+	})
+
+	return f.now
+}
+
+// With makes the time-aware parts of a run executed with the returned context follow clock.
+func With(ctx context.Context, clock Clock) context.Context {
+	return context.WithValue(ctx, clockKey{}, clock)
+}
+
+// From returns the clock of a run, the wall clock unless another one was set.
+func From(ctx context.Context) Clock {
+	if clock, ok := ctx.Value(clockKey{}).(Clock); ok {
+		return clock
+	}
+
+	return Real
+}
@@ -0,0 +1,56 @@
+package clock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScaled(t *testing.T) {
+	assert.Equal(t, Real, NewScaled(1))
+
+	clock := NewScaled(1000)
+	start := clock.Now()
+
+	begin := time.Now()
+	at := <-clock.After(10 * time.Second)
+
+	assert.Less(t, time.Since(begin), time.Second, "ten seconds take ten milliseconds at 1000x")
+	assert.GreaterOrEqual(t, at.Sub(start), 10*time.Second)
+}
+
+func TestFake(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFake(start)
+
+	minute := clock.After(time.Minute)
+	hour := clock.After(time.Hour)
+	assert.Equal(t, start, <-clock.After(0), "waits that are over are due right away")
+
+	clock.Advance(30 * time.Second)
+	assert.Empty(t, minute)
+
+	clock.Advance(30 * time.Second)
+	assert.Equal(t, start.Add(time.Minute), <-minute)
+	assert.Empty(t, hour)
+	assert.Equal(t, start.Add(time.Minute), clock.Now())
+}
+
+func TestInstant(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewInstant(start)
+
+	assert.Equal(t, start.Add(time.Minute), <-clock.After(time.Minute))
+	assert.Equal(t, start.Add(time.Minute), <-clock.After(-time.Second), "negative waits are due right away")
+	assert.Equal(t, start.Add(2*time.Minute), clock.Advance(time.Minute))
+	assert.Equal(t, start.Add(2*time.Minute), clock.Now())
+}
+
+func TestFrom(t *testing.T) {
+	assert.Equal(t, Real, From(context.Background()))
+
+	fake := NewInstant(time.Now())
+	assert.Equal(t, Clock(fake), From(With(context.Background(), fake)))
+}
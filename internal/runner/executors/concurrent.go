@@ -6,13 +6,14 @@ import (
 	"fmt"
 	"log/slog"
 
-	"github.com/neonmei/szgen/internal/consts"
+	"github.com/neonmei/szgen/internal/config"
 	"github.com/neonmei/szgen/internal/runner"
 	"golang.org/x/sync/errgroup"
 )
 
 type concurrentExecutor struct {
 	maxConcurrency int
+	policy         config.FailurePolicy
 }
 
 func (e *concurrentExecutor) Execute(ctx context.Context, tasks []runner.Task) error {
@@ -21,6 +22,7 @@ func (e *concurrentExecutor) Execute(ctx context.Context, tasks []runner.Task) e
 		return nil
 	}
 
+	failures := newFailures(e.policy)
 	g, ctx := errgroup.WithContext(ctx)
 
 	if e.maxConcurrency > 0 {
@@ -29,11 +31,16 @@ func (e *concurrentExecutor) Execute(ctx context.Context, tasks []runner.Task) e
 
 	for _, task := range tasks {
 		g.Go(func() error {
-			return e.executeWithRecovery(ctx, task)
+			return failures.run(ctx, task)
 		})
 	}
 
-	if err := g.Wait(); err != nil {
+	err := g.Wait()
+	if err == nil {
+		err = failures.err()
+	}
+
+	if err != nil {
 		slog.Error("concurrent executor failed", "error", err)
 		return err
 	}
@@ -42,36 +49,23 @@ func (e *concurrentExecutor) Execute(ctx context.Context, tasks []runner.Task) e
 	return nil
 }
 
-func (e *concurrentExecutor) executeWithRecovery(ctx context.Context, task runner.Task) (taskErr error) {
+// executeWithRecovery runs a task turning panics into errors, cancellation is not an error.
+func executeWithRecovery(ctx context.Context, task runner.Task) (taskErr error) {
 	defer func() {
 		if r := recover(); r != nil {
-			taskErr = fmt.Errorf("task panicked: %v", r)
+			taskErr = fmt.Errorf("task %q panicked: %v", task.Name(), r)
 		}
 	}()
 
 	if err := task.Execute(ctx); err != nil && !errors.Is(err, context.Canceled) {
-		return fmt.Errorf("task aborted: %w", err)
+		return fmt.Errorf("task %q aborted: %w", task.Name(), err)
 	}
 	return nil
 }
 
-func NewConcurrent(params map[string]any) *concurrentExecutor {
+func NewConcurrent(maxConcurrency int, policy config.FailurePolicy) *concurrentExecutor {
 	return &concurrentExecutor{
-		maxConcurrency: intParam(params, consts.ParamMaxConcurrency, 0),
-	}
-}
-
-func intParam(params map[string]any, key string, fallback int) int {
-	val, ok := params[key]
-	if !ok {
-		return fallback
-	}
-	switch v := val.(type) {
-	case int:
-		return v
-	case float64:
-		return int(v)
-	default:
-		return fallback
+		maxConcurrency: maxConcurrency,
+		policy:         policy,
 	}
 }
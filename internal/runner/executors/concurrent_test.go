@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/neonmei/szgen/internal/config"
 	"github.com/neonmei/szgen/internal/runner"
 	"github.com/neonmei/szgen/internal/runner/mocks"
 	"github.com/stretchr/testify/assert"
@@ -29,7 +30,7 @@ func TestConcurrentExecutor_Execute(t *testing.T) {
 
 		// With concurrency 5, it should take approx 2 * 10ms = 20ms (+ overhead)
 		// Sequential would take 100ms
-		exec := NewConcurrent(map[string]any{"max_concurrency": 5})
+		exec := NewConcurrent(5, config.FailurePolicy{})
 
 		start := time.Now()
 		err := exec.Execute(context.Background(), tasks)
@@ -47,25 +48,30 @@ func TestConcurrentExecutor_Execute(t *testing.T) {
 			&mocks.MockTask{NameVal: "task3", ExecuteTime: 50 * time.Millisecond},
 		}
 
-		exec := NewConcurrent(map[string]any{"max_concurrency": 2})
+		exec := NewConcurrent(2, config.FailurePolicy{})
 		err := exec.Execute(context.Background(), tasks)
 		assert.Error(t, err)
 	})
 
 	t.Run("context cancellation", func(t *testing.T) {
+		started := make(chan struct{})
 		tasks := []runner.Task{
-			&mocks.MockTask{NameVal: "task1", ExecuteTime: 100 * time.Millisecond},
+			&mocks.MockTask{NameVal: "task1", ExecuteFunc: func(ctx context.Context) error {
+				close(started)
+				<-ctx.Done()
+				return ctx.Err()
+			}},
 		}
 
 		ctx, cancel := context.WithCancel(context.Background())
 
-		// Cancel shortly after start
+		// Cancel once the task is running
 		go func() {
-			time.Sleep(10 * time.Millisecond)
+			<-started
 			cancel()
 		}()
 
-		exec := NewConcurrent(map[string]any{})
+		exec := NewConcurrent(0, config.FailurePolicy{})
 		err := exec.Execute(ctx, tasks)
 
 		// Should return nil (successful completion of *what was possible* or error?)
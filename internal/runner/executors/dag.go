@@ -0,0 +1,144 @@
+package executors
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/runner"
+	"github.com/neonmei/szgen/internal/runner/clock"
+	"golang.org/x/sync/errgroup"
+)
+
+// dagExecutor runs tasks as soon as their schedule allows it: independent branches run
+// concurrently, while tasks depending on others wait for every task with those names to
+// complete. Concurrency is limited on running tasks only, so tasks waiting for their
+// dependencies never hold a slot.
+type dagExecutor struct {
+	maxConcurrency int
+	schedules      config.ExecutorConfig
+}
+
+func (e *dagExecutor) Execute(ctx context.Context, tasks []runner.Task) error {
+	if len(tasks) == 0 {
+		slog.Info("dag executor finished", "tasks", 0)
+		return nil
+	}
+
+	// done is closed once every task with a given name completed
+	pending := make(map[string]int)
+	for _, task := range tasks {
+		pending[task.Name()]++
+	}
+
+	done := make(map[string]chan struct{}, len(pending))
+	for name := range pending {
+		done[name] = make(chan struct{})
+	}
+
+	// tasks that are not part of the run, e.g. of another shard, don't hold their dependents back
+	for _, schedule := range e.schedules.Tasks {
+		for _, name := range append([]string{schedule.Name}, schedule.DependsOn...) {
+			if _, ok := done[name]; !ok {
+				slog.Warn("Scheduled task not found, dependents won't wait for it", "task", name)
+				ch := make(chan struct{})
+				close(ch)
+				done[name] = ch
+			}
+		}
+	}
+
+	completed := make(chan string, len(tasks))
+	go func() {
+		for name := range completed {
+			if pending[name]--; pending[name] == 0 {
+				close(done[name])
+			}
+		}
+	}()
+	defer close(completed)
+
+	var slots chan struct{}
+	if e.maxConcurrency > 0 {
+		slots = make(chan struct{}, e.maxConcurrency)
+	}
+
+	failures := newFailures(e.schedules.FailurePolicy)
+	g, ctx := errgroup.WithContext(ctx)
+	c := clock.From(ctx)
+	start := c.Now()
+
+	for _, task := range tasks {
+		schedule, _ := e.schedules.Schedule(task.Name())
+
+		g.Go(func() error {
+			if err := e.wait(ctx, schedule, done, start); err != nil {
+				return nil
+			}
+
+			if slots != nil {
+				select {
+				case slots <- struct{}{}:
+					defer func() { <-slots }()
+				case <-ctx.Done():
+					return nil
+				}
+			}
+
+			slog.Debug("Starting task", "task", task.Name(), "elapsed", c.Now().Sub(start).Round(time.Millisecond))
+			// a task failing fast cancels the run before its dependents can start
+			err := failures.run(ctx, task)
+			if err == nil {
+				completed <- task.Name()
+			}
+
+			return err
+		})
+	}
+
+	err := g.Wait()
+	if err == nil {
+		err = failures.err()
+	}
+
+	if err != nil {
+		slog.Error("dag executor failed", "error", err)
+		return err
+	}
+
+	slog.Info("dag executor finished successfully", "tasks", len(tasks))
+	return nil
+}
+
+// wait blocks until the dependencies of a task completed, then honours its delays.
+func (e *dagExecutor) wait(ctx context.Context, schedule config.TaskSchedule, done map[string]chan struct{}, start time.Time) error {
+	for _, dependency := range schedule.DependsOn {
+		select {
+		case <-done[dependency]:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	c := clock.From(ctx)
+	delay := max(schedule.StartAfter, start.Add(schedule.StartAt).Sub(c.Now()))
+	if delay > 0 {
+		slog.Info("Task scheduled", "task", schedule.Name, "delay", delay.Round(time.Millisecond))
+
+		select {
+		case <-c.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+func NewDAG(cfg config.ExecutorConfig, maxConcurrency int) *dagExecutor {
+	return &dagExecutor{
+		maxConcurrency: maxConcurrency,
+		schedules:      cfg,
+	}
+}
@@ -0,0 +1,140 @@
+package executors
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/consts"
+	"github.com/neonmei/szgen/internal/runner"
+	"github.com/neonmei/szgen/internal/runner/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+)
+
+// timeline records when every task started and finished.
+type timeline struct {
+	mu       sync.Mutex
+	start    time.Time
+	started  map[string]time.Duration
+	finished map[string]time.Duration
+}
+
+func newTimeline() *timeline {
+	return &timeline{start: time.Now(), started: make(map[string]time.Duration), finished: make(map[string]time.Duration)}
+}
+
+func (tl *timeline) task(name string, duration time.Duration) runner.Task {
+	return &mocks.MockTask{
+		NameVal: name,
+		ExecuteFunc: func(ctx context.Context) error {
+			tl.mu.Lock()
+			tl.started[name] = time.Since(tl.start)
+			tl.mu.Unlock()
+
+			select {
+			case <-time.After(duration):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+
+			tl.mu.Lock()
+			tl.finished[name] = time.Since(tl.start)
+			tl.mu.Unlock()
+			return nil
+		},
+	}
+}
+
+func newDAGConfig(schedules ...config.TaskSchedule) config.ExecutorConfig {
+	return config.NewExecutorConfig(
+		config.WithExecutorStrategy(consts.ExecutorStrategyDAG),
+		config.WithTaskSchedules(schedules),
+	)
+}
+
+func TestDAGExecutor_Execute(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	t.Run("dependencies and delays", func(t *testing.T) {
+		tl := newTimeline()
+		tasks := []runner.Task{
+			tl.task("load", 10*time.Millisecond),
+			tl.task("warmup", 30*time.Millisecond),
+			tl.task("warmup", 20*time.Millisecond),
+			tl.task("independent", 10*time.Millisecond),
+			tl.task("spike", 10*time.Millisecond),
+		}
+
+		exec := NewDAG(newDAGConfig(
+			config.TaskSchedule{Name: "warmup"},
+			config.TaskSchedule{Name: "load", DependsOn: []string{"warmup"}, StartAfter: 20 * time.Millisecond},
+			config.TaskSchedule{Name: "spike", StartAt: 40 * time.Millisecond},
+		), 0)
+		require.NoError(t, exec.Execute(context.Background(), tasks))
+
+		assert.Less(t, tl.started["independent"], 20*time.Millisecond, "independent tasks start right away")
+		assert.GreaterOrEqual(t, tl.started["load"], tl.finished["warmup"]+20*time.Millisecond, "load waits for every warmup task")
+		assert.GreaterOrEqual(t, tl.started["spike"], 40*time.Millisecond)
+		assert.Less(t, tl.started["spike"], tl.started["load"], "spike doesn't wait for load")
+	})
+
+	t.Run("max concurrency only limits running tasks", func(t *testing.T) {
+		tl := newTimeline()
+		tasks := []runner.Task{
+			tl.task("second", 10*time.Millisecond),
+			tl.task("first", 10*time.Millisecond),
+		}
+
+		exec := NewDAG(newDAGConfig(
+			config.TaskSchedule{Name: "first"},
+			config.TaskSchedule{Name: "second", DependsOn: []string{"first"}},
+		), 1)
+		require.NoError(t, exec.Execute(context.Background(), tasks))
+		assert.GreaterOrEqual(t, tl.started["second"], tl.finished["first"])
+	})
+
+	t.Run("unknown scheduled tasks don't block", func(t *testing.T) {
+		exec := NewDAG(newDAGConfig(
+			config.TaskSchedule{Name: "missing"},
+			config.TaskSchedule{Name: "task", DependsOn: []string{"missing"}},
+		), 0)
+		require.NoError(t, exec.Execute(context.Background(), []runner.Task{&mocks.MockTask{NameVal: "task"}}))
+	})
+
+	t.Run("unknown dependencies don't block", func(t *testing.T) {
+		exec := NewDAG(newDAGConfig(config.TaskSchedule{Name: "task", DependsOn: []string{"missing"}}), 0)
+		require.NoError(t, exec.Execute(context.Background(), []runner.Task{&mocks.MockTask{NameVal: "task"}}))
+	})
+
+	t.Run("abort on error", func(t *testing.T) {
+		dependent := &mocks.MockTask{NameVal: "dependent"}
+		tasks := []runner.Task{
+			&mocks.MockTask{NameVal: "error-task", ExecuteErr: errors.New("failed")},
+			dependent,
+		}
+
+		exec := NewDAG(newDAGConfig(
+			config.TaskSchedule{Name: "error-task"},
+			config.TaskSchedule{Name: "dependent", DependsOn: []string{"error-task"}},
+		), 0)
+		err := exec.Execute(context.Background(), tasks)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `task "error-task" aborted`)
+		assert.Zero(t, dependent.ExecuteCalled)
+	})
+
+	t.Run("no tasks", func(t *testing.T) {
+		require.NoError(t, NewDAG(newDAGConfig(), 0).Execute(context.Background(), nil))
+	})
+}
+
+func TestNew_DAG(t *testing.T) {
+	exec, err := New(newDAGConfig())
+	require.NoError(t, err)
+	assert.IsType(t, &dagExecutor{}, exec)
+}
@@ -8,16 +8,63 @@ import (
 	"github.com/neonmei/szgen/internal/runner"
 )
 
-func New(cfg config.ExecutorConfig) (runner.Executor, error) {
+func New(cfg config.ExecutorConfig, opts ...Option) (runner.Executor, error) {
 	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid executor configuration: %w", err)
 	}
 
+	exec, err := newStrategy(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	o := newOptions(opts...)
+	if cfg.Repeat.Repeats() || len(o.hooks) > 0 {
+		exec = NewRepeat(exec, cfg.Repeat, cfg.PauseBetween, o.hooks...)
+	}
+
+	if cfg.TimeScale > 0 && cfg.TimeScale != 1 {
+		exec = NewScaled(exec, cfg.TimeScale)
+	}
+
+	budget, err := cfg.MaxPointsPerSecond()
+	if err != nil {
+		return nil, err
+	}
+
+	if budget > 0 {
+		exec = NewThrottled(exec, budget)
+	}
+
+	if o.report != nil {
+		exec = NewReported(exec, o.report)
+	}
+
+	return exec, nil
+}
+
+func newStrategy(cfg config.ExecutorConfig) (runner.Executor, error) {
 	switch cfg.Strategy {
 	case consts.ExecutorStrategySerial:
-		return NewSerial(), nil
+		return NewSerial(cfg.FailurePolicy), nil
 	case consts.ExecutorStrategyConcurrent:
-		return NewConcurrent(cfg.Params), nil
+		maxConcurrency, err := cfg.MaxConcurrency()
+		if err != nil {
+			return nil, err
+		}
+		return NewConcurrent(maxConcurrency, cfg.FailurePolicy), nil
+	case consts.ExecutorStrategyDAG:
+		maxConcurrency, err := cfg.MaxConcurrency()
+		if err != nil {
+			return nil, err
+		}
+		return NewDAG(cfg, maxConcurrency), nil
+	case consts.ExecutorStrategyRamp:
+		ramp, err := cfg.Ramp()
+		if err != nil {
+			return nil, err
+		}
+		return NewRamp(ramp, cfg.FailurePolicy), nil
 	default:
 		return nil, fmt.Errorf("unknown executor strategy: %s", cfg.Strategy)
 	}
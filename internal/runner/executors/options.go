@@ -0,0 +1,39 @@
+package executors
+
+import (
+	"context"
+
+	"github.com/neonmei/szgen/internal/runner"
+)
+
+type (
+	Option  func(*options)
+	options struct {
+		hooks  []func(context.Context)
+		report *runner.Report
+	}
+)
+
+func newOptions(opts ...Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// WithIterationHook calls hook at the beginning of every iteration of the task list,
+// e.g. to restart the phases timeline, with a context ending with the iteration.
+func WithIterationHook(hook func(context.Context)) Option {
+	return func(o *options) {
+		o.hooks = append(o.hooks, hook)
+	}
+}
+
+// WithReport tracks what every task records in a run report.
+func WithReport(report *runner.Report) Option {
+	return func(o *options) {
+		o.report = report
+	}
+}
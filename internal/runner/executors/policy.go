@@ -0,0 +1,79 @@
+package executors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/runner"
+	"github.com/neonmei/szgen/internal/runner/clock"
+)
+
+// failures runs tasks under a failure policy and keeps the errors of the tasks that
+// failed for good, so the run can go on and still report them. Safe for concurrent use.
+type failures struct {
+	policy config.FailurePolicy
+
+	mu   sync.Mutex
+	errs []error
+}
+
+func newFailures(policy config.FailurePolicy) *failures {
+	return &failures{policy: policy}
+}
+
+// run executes a task, retrying it when the policy asks for it. The error is returned
+// under fail_fast only, otherwise it's kept for the end of the run.
+func (f *failures) run(ctx context.Context, task runner.Task) error {
+	err := f.attempt(ctx, task)
+	if err == nil || f.policy.FailFast() {
+		return err
+	}
+
+	slog.Warn("Task failed, continuing", "task", task.Name(), "error", err)
+
+	f.mu.Lock()
+	f.errs = append(f.errs, err)
+	f.mu.Unlock()
+
+	return nil
+}
+
+func (f *failures) attempt(ctx context.Context, task runner.Task) error {
+	attempts := f.policy.Attempts()
+
+	for attempt := 1; ; attempt++ {
+		err := executeWithRecovery(ctx, task)
+		if err == nil || attempts == 1 {
+			return err
+		}
+
+		if attempt == attempts || ctx.Err() != nil {
+			return fmt.Errorf("%w (after %d attempts)", err, attempt)
+		}
+
+		backoff := f.policy.RetryBackoff(attempt)
+		slog.Warn("Task failed, retrying", "task", task.Name(), "attempt", attempt, "backoff", backoff, "error", err)
+
+		select {
+		case <-clock.From(ctx).After(backoff):
+		case <-ctx.Done():
+			return fmt.Errorf("%w (after %d attempts)", err, attempt)
+		}
+	}
+}
+
+// err aggregates the errors of every failed task, nil if none failed.
+func (f *failures) err() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if len(f.errs) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%d task(s) failed:\n%w", len(f.errs), errors.Join(f.errs...))
+}
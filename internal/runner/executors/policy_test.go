@@ -0,0 +1,103 @@
+package executors
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/consts"
+	"github.com/neonmei/szgen/internal/runner"
+	"github.com/neonmei/szgen/internal/runner/clock"
+	"github.com/neonmei/szgen/internal/runner/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+)
+
+// flaky fails the first failures executions.
+func flaky(name string, failures int) *mocks.MockTask {
+	task := &mocks.MockTask{NameVal: name}
+	task.ExecuteFunc = func(context.Context) error {
+		task.Mu.Lock()
+		defer task.Mu.Unlock()
+
+		if task.ExecuteCalled <= failures {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+
+	return task
+}
+
+func TestFailurePolicy(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	strategies := map[string]func(config.FailurePolicy) runner.Executor{
+		consts.ExecutorStrategySerial: func(fp config.FailurePolicy) runner.Executor { return NewSerial(fp) },
+		consts.ExecutorStrategyConcurrent: func(fp config.FailurePolicy) runner.Executor {
+			return NewConcurrent(0, fp)
+		},
+	}
+
+	for strategy, newExecutor := range strategies {
+		t.Run(strategy, func(t *testing.T) {
+			t.Run("fail fast", func(t *testing.T) {
+				tasks := []runner.Task{flaky("broken", 1), &mocks.MockTask{NameVal: "slow", ExecuteTime: time.Minute}}
+
+				start := time.Now()
+				err := newExecutor(config.FailurePolicy{Mode: consts.FailurePolicyFailFast}).Execute(context.Background(), tasks)
+				require.EqualError(t, err, `task "broken" aborted: connection refused`)
+				assert.Less(t, time.Since(start), time.Second)
+			})
+
+			t.Run("continue", func(t *testing.T) {
+				healthy := &mocks.MockTask{NameVal: "healthy"}
+				tasks := []runner.Task{flaky("first", 1), healthy, flaky("second", 1)}
+
+				err := newExecutor(config.FailurePolicy{Mode: consts.FailurePolicyContinue}).Execute(context.Background(), tasks)
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "2 task(s) failed")
+				assert.Contains(t, err.Error(), `task "first" aborted: connection refused`)
+				assert.Contains(t, err.Error(), `task "second" aborted: connection refused`)
+				assert.Equal(t, 1, healthy.ExecuteCalled)
+			})
+
+			t.Run("retry", func(t *testing.T) {
+				recovering, broken := flaky("recovering", 2), flaky("broken", 5)
+				tasks := []runner.Task{recovering, broken}
+
+				policy := config.FailurePolicy{Mode: consts.FailurePolicyRetry, MaxAttempts: 3, Backoff: time.Millisecond}
+				err := newExecutor(policy).Execute(context.Background(), tasks)
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "1 task(s) failed")
+				assert.Contains(t, err.Error(), `task "broken" aborted: connection refused (after 3 attempts)`)
+				assert.Equal(t, 3, recovering.ExecuteCalled)
+				assert.Equal(t, 3, broken.ExecuteCalled)
+			})
+		})
+	}
+}
+
+func TestFailures_RetryCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	task := flaky("broken", 5)
+	policy := config.FailurePolicy{Mode: consts.FailurePolicyRetry, MaxAttempts: 5, Backoff: time.Minute}
+	err := newFailures(policy).attempt(ctx, task)
+	assert.EqualError(t, err, `task "broken" aborted: connection refused (after 1 attempts)`)
+}
+
+func TestFailures_RetryBackoffOnClock(t *testing.T) {
+	c := clock.NewInstant(time.Now())
+	start := c.Now()
+
+	task := flaky("recovering", 2)
+	policy := config.FailurePolicy{Mode: consts.FailurePolicyRetry, MaxAttempts: 3, Backoff: time.Minute}
+	require.NoError(t, newFailures(policy).attempt(clock.With(context.Background(), c), task))
+	assert.Equal(t, 3, task.ExecuteCalled)
+	assert.GreaterOrEqual(t, c.Now().Sub(start), 3*time.Minute, "backoffs wait on the clock of the run")
+}
@@ -0,0 +1,143 @@
+package executors
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/runner"
+	"github.com/neonmei/szgen/internal/runner/clock"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	stageRampUp   = "ramp_up"
+	stageHold     = "hold"
+	stageRampDown = "ramp_down"
+)
+
+// rampExecutor scales the amount of running tasks like load testing tools do: tasks are
+// started in order while ramping up and cancelled in reverse order while ramping down.
+// The run lasts as long as the ramp, a task completing on its own keeps its slot.
+type rampExecutor struct {
+	ramp   config.RampConfig
+	policy config.FailurePolicy
+}
+
+// rampStep sets the amount of active tasks at an offset from the beginning of the ramp,
+// steps starting a stage carry its name.
+type rampStep struct {
+	at     time.Duration
+	active int
+	stage  string
+}
+
+func (e *rampExecutor) Execute(ctx context.Context, tasks []runner.Task) error {
+	if len(tasks) == 0 {
+		slog.Info("ramp executor finished", "tasks", 0)
+		return nil
+	}
+
+	end := e.ramp.End
+	if end == 0 || end > len(tasks) {
+		if end > len(tasks) {
+			slog.Warn("Ramp end exceeds the number of tasks, ramping up to every task", "end", end, "tasks", len(tasks))
+		}
+		end = len(tasks)
+	}
+	start := min(e.ramp.Start, end)
+
+	failures := newFailures(e.policy)
+	g, gctx := errgroup.WithContext(ctx)
+
+	var cancels []context.CancelFunc
+	active := 0
+	scale := func(target int) {
+		for ; active < target; active++ {
+			taskCtx, cancel := context.WithCancel(gctx)
+			cancels = append(cancels, cancel)
+
+			task := tasks[active]
+			g.Go(func() error {
+				defer cancel()
+				return failures.run(taskCtx, task)
+			})
+		}
+
+		for ; active > target; active-- {
+			cancels[active-1]()
+		}
+	}
+
+	c := clock.From(ctx)
+	begin := c.Now()
+
+steps:
+	for _, step := range e.steps(start, end) {
+		if wait := begin.Add(step.at).Sub(c.Now()); wait > 0 {
+			select {
+			case <-c.After(wait):
+			case <-gctx.Done():
+				break steps
+			}
+		}
+
+		if step.active != active {
+			slog.Debug("Ramp scaled", "active", step.active, "elapsed", c.Now().Sub(begin).Round(time.Millisecond))
+		}
+		scale(step.active)
+
+		if step.stage != "" {
+			slog.Info("Ramp stage started", "stage", step.stage, "active", active)
+		}
+	}
+
+	scale(0)
+
+	err := g.Wait()
+	if err == nil {
+		err = failures.err()
+	}
+
+	if err != nil {
+		slog.Error("ramp executor failed", "error", err)
+		return err
+	}
+
+	slog.Info("ramp executor finished successfully", "tasks", len(cancels))
+	return nil
+}
+
+// steps lays out the ramp: every change of the active tasks is spread evenly over its
+// stage, stages without duration are not announced.
+func (e *rampExecutor) steps(start, end int) []rampStep {
+	rc := e.ramp
+	span := end - start
+
+	stage := func(name string, duration time.Duration) string {
+		if duration == 0 {
+			return ""
+		}
+		return name
+	}
+
+	steps := []rampStep{{at: 0, active: start, stage: stage(stageRampUp, rc.RampUp)}}
+	for i := 1; i <= span; i++ {
+		steps = append(steps, rampStep{at: rc.RampUp * time.Duration(i) / time.Duration(span), active: start + i})
+	}
+
+	steps = append(steps, rampStep{at: rc.RampUp, active: end, stage: stage(stageHold, rc.Hold)})
+
+	offset := rc.RampUp + rc.Hold
+	steps = append(steps, rampStep{at: offset, active: end, stage: stage(stageRampDown, rc.RampDown)})
+	for i := 1; i <= span; i++ {
+		steps = append(steps, rampStep{at: offset + rc.RampDown*time.Duration(i)/time.Duration(span), active: end - i})
+	}
+
+	return steps
+}
+
+func NewRamp(ramp config.RampConfig, policy config.FailurePolicy) *rampExecutor {
+	return &rampExecutor{ramp: ramp, policy: policy}
+}
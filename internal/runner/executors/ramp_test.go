@@ -0,0 +1,125 @@
+package executors
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/consts"
+	"github.com/neonmei/szgen/internal/runner"
+	"github.com/neonmei/szgen/internal/runner/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+)
+
+func TestRampExecutor_Steps(t *testing.T) {
+	exec := NewRamp(config.RampConfig{RampUp: 30 * time.Second, Hold: time.Minute, RampDown: 30 * time.Second}, config.FailurePolicy{})
+
+	assert.Equal(t, []rampStep{
+		{at: 0, active: 1, stage: stageRampUp},
+		{at: 10 * time.Second, active: 2},
+		{at: 20 * time.Second, active: 3},
+		{at: 30 * time.Second, active: 4},
+		{at: 30 * time.Second, active: 4, stage: stageHold},
+		{at: 90 * time.Second, active: 4, stage: stageRampDown},
+		{at: 100 * time.Second, active: 3},
+		{at: 110 * time.Second, active: 2},
+		{at: 120 * time.Second, active: 1},
+	}, exec.steps(1, 4))
+
+	t.Run("stages without duration are not announced", func(t *testing.T) {
+		exec := NewRamp(config.RampConfig{Hold: time.Minute}, config.FailurePolicy{})
+		assert.Equal(t, []rampStep{
+			{at: 0, active: 2},
+			{at: 0, active: 3},
+			{at: 0, active: 3, stage: stageHold},
+			{at: time.Minute, active: 3},
+			{at: time.Minute, active: 2},
+		}, exec.steps(2, 3))
+	})
+}
+
+func TestRampExecutor_Execute(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	t.Run("starts and cancels tasks on schedule", func(t *testing.T) {
+		var (
+			mu      sync.Mutex
+			begin   = time.Now()
+			started = make(map[string]time.Duration)
+			stopped = make(map[string]time.Duration)
+		)
+
+		task := func(name string) runner.Task {
+			return &mocks.MockTask{
+				NameVal: name,
+				ExecuteFunc: func(ctx context.Context) error {
+					mu.Lock()
+					started[name] = time.Since(begin)
+					mu.Unlock()
+
+					<-ctx.Done()
+
+					mu.Lock()
+					stopped[name] = time.Since(begin)
+					mu.Unlock()
+					return ctx.Err()
+				},
+			}
+		}
+
+		tasks := []runner.Task{task("first"), task("second"), task("third")}
+		exec := NewRamp(config.RampConfig{Start: 1, RampUp: 40 * time.Millisecond, Hold: 20 * time.Millisecond, RampDown: 40 * time.Millisecond}, config.FailurePolicy{})
+		require.NoError(t, exec.Execute(context.Background(), tasks))
+
+		assert.Less(t, started["first"], 15*time.Millisecond)
+		assert.GreaterOrEqual(t, started["second"], 20*time.Millisecond)
+		assert.GreaterOrEqual(t, started["third"], 40*time.Millisecond)
+
+		assert.GreaterOrEqual(t, stopped["third"], 80*time.Millisecond, "ramp down cancels the last started task first")
+		assert.GreaterOrEqual(t, stopped["second"], 100*time.Millisecond)
+		assert.Less(t, stopped["third"], stopped["second"])
+		assert.GreaterOrEqual(t, stopped["first"], 100*time.Millisecond, "the run ends with the ramp")
+	})
+
+	t.Run("end beyond the number of tasks", func(t *testing.T) {
+		tasks := []runner.Task{&mocks.MockTask{NameVal: "task"}}
+		exec := NewRamp(config.RampConfig{Start: 1, End: 5, Hold: 10 * time.Millisecond}, config.FailurePolicy{})
+		require.NoError(t, exec.Execute(context.Background(), tasks))
+	})
+
+	t.Run("abort on error", func(t *testing.T) {
+		tasks := []runner.Task{&mocks.MockTask{NameVal: "error-task", ExecuteErr: errors.New("failed")}}
+
+		start := time.Now()
+		err := NewRamp(config.RampConfig{Start: 1, Hold: time.Minute}, config.FailurePolicy{}).Execute(context.Background(), tasks)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `task "error-task" aborted`)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("context cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(20*time.Millisecond, cancel)
+
+		tasks := []runner.Task{&mocks.MockTask{NameVal: "task", ExecuteTime: time.Minute}}
+		require.NoError(t, NewRamp(config.RampConfig{Start: 1, Hold: time.Minute}, config.FailurePolicy{}).Execute(ctx, tasks))
+	})
+}
+
+func TestNew_Ramp(t *testing.T) {
+	exec, err := New(config.NewExecutorConfig(
+		config.WithExecutorStrategy(consts.ExecutorStrategyRamp),
+		config.WithExecutorParams(map[string]any{consts.ParamRampUp: "1m", consts.ParamRampHold: 30}),
+	))
+	require.NoError(t, err)
+	require.IsType(t, &rampExecutor{}, exec)
+	assert.Equal(t, config.RampConfig{Start: 1, RampUp: time.Minute, Hold: 30 * time.Second}, exec.(*rampExecutor).ramp)
+
+	_, err = New(config.NewExecutorConfig(config.WithExecutorStrategy(consts.ExecutorStrategyRamp)))
+	assert.Error(t, err)
+}
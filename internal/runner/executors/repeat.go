@@ -0,0 +1,81 @@
+package executors
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/runner"
+	"github.com/neonmei/szgen/internal/runner/clock"
+)
+
+// repeatExecutor runs the task list once per iteration. Generators are built once with
+// their task, but every execution ranges over their sequence again, so every iteration
+// starts over from the configured values.
+// Hooks run at the beginning of every iteration with a context ending with it.
+type repeatExecutor struct {
+	executor runner.Executor
+	repeat   config.Repeat
+	pause    time.Duration
+	hooks    []func(context.Context)
+}
+
+func (e *repeatExecutor) Execute(ctx context.Context, tasks []runner.Task) error {
+	for iteration := 1; e.repeat == config.InfiniteRepeat || iteration <= max(int(e.repeat), 1); iteration++ {
+		if iteration > 1 && e.pause > 0 {
+			slog.Info("Pausing before next iteration", "iteration", iteration, "pause", e.pause)
+
+			select {
+			case <-clock.From(ctx).After(e.pause):
+			case <-ctx.Done():
+			}
+		}
+
+		if iteration > 1 && ctx.Err() != nil {
+			slog.Info("Repeat interrupted", "iterations", iteration-1)
+			return nil
+		}
+
+		if e.repeat.Repeats() {
+			slog.Info("Iteration started", "iteration", iteration, "repeat", e.repeat)
+		}
+
+		if err := e.iterate(runner.WithIteration(ctx, iteration), tasks); err != nil {
+			if !e.repeat.Repeats() {
+				return err
+			}
+
+			// looping until interrupted is the way soak tests end
+			if ctx.Err() != nil {
+				slog.Info("Repeat interrupted", "iteration", iteration)
+				return nil
+			}
+
+			return fmt.Errorf("iteration %d: %w", iteration, err)
+		}
+	}
+
+	return nil
+}
+
+func (e *repeatExecutor) iterate(ctx context.Context, tasks []runner.Task) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for _, hook := range e.hooks {
+		hook(ctx)
+	}
+
+	return e.executor.Execute(ctx, tasks)
+}
+
+func NewRepeat(executor runner.Executor, repeat config.Repeat, pause time.Duration, hooks ...func(context.Context)) *repeatExecutor {
+	return &repeatExecutor{
+		executor: executor,
+		repeat:   repeat,
+		pause:    pause,
+		hooks:    hooks,
+	}
+}
@@ -0,0 +1,97 @@
+package executors
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/consts"
+	"github.com/neonmei/szgen/internal/runner"
+	"github.com/neonmei/szgen/internal/runner/clock"
+	"github.com/neonmei/szgen/internal/runner/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+)
+
+func TestRepeatExecutor_Execute(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	t.Run("repeat count with pause", func(t *testing.T) {
+		task := &mocks.MockTask{NameVal: "task"}
+
+		var iterations []context.Context
+		hook := func(ctx context.Context) { iterations = append(iterations, ctx) }
+
+		exec := NewRepeat(NewSerial(config.FailurePolicy{}), 3, time.Minute, hook)
+
+		start := time.Now()
+		fake := clock.NewInstant(start)
+		require.NoError(t, exec.Execute(clock.With(context.Background(), fake), []runner.Task{task}))
+		assert.Equal(t, start.Add(2*time.Minute), fake.Now(), "pauses between iterations only")
+		assert.Equal(t, 3, task.ExecuteCalled)
+
+		require.Len(t, iterations, 3)
+		for _, ctx := range iterations {
+			assert.Error(t, ctx.Err(), "hook contexts end with their iteration")
+		}
+	})
+
+	t.Run("infinite until interrupted", func(t *testing.T) {
+		var calls atomic.Int32
+		ctx, cancel := context.WithCancel(context.Background())
+
+		task := &mocks.MockTask{
+			NameVal: "task",
+			ExecuteFunc: func(ctx context.Context) error {
+				if calls.Add(1) == 5 {
+					cancel()
+				}
+				return nil
+			},
+		}
+
+		exec := NewRepeat(NewConcurrent(0, config.FailurePolicy{}), config.InfiniteRepeat, 0)
+		require.NoError(t, exec.Execute(ctx, []runner.Task{task}))
+		assert.Equal(t, int32(5), calls.Load())
+	})
+
+	t.Run("failed iteration stops", func(t *testing.T) {
+		task := &mocks.MockTask{NameVal: "broken", ExecuteErr: errors.New("failed")}
+
+		exec := NewRepeat(NewSerial(config.FailurePolicy{}), 3, 0)
+		err := exec.Execute(context.Background(), []runner.Task{task})
+		require.EqualError(t, err, `iteration 1: task "broken" aborted: failed`)
+		assert.Equal(t, 1, task.ExecuteCalled)
+	})
+
+	t.Run("single run keeps the executor error", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		exec := NewRepeat(NewSerial(config.FailurePolicy{}), 0, 0)
+		assert.ErrorIs(t, exec.Execute(ctx, []runner.Task{&mocks.MockTask{NameVal: "task"}}), context.Canceled)
+	})
+}
+
+func TestNew_Repeat(t *testing.T) {
+	exec, err := New(config.NewExecutorConfig(config.WithRepeat(2, time.Second)))
+	require.NoError(t, err)
+	assert.IsType(t, &repeatExecutor{}, exec)
+
+	exec, err = New(config.NewExecutorConfig(), WithIterationHook(func(context.Context) {}))
+	require.NoError(t, err)
+	assert.IsType(t, &repeatExecutor{}, exec, "hooks run even without repeat")
+
+	exec, err = New(config.NewExecutorConfig(
+		config.WithExecutorStrategy(consts.ExecutorStrategyConcurrent),
+		config.WithRepeat(config.InfiniteRepeat, 0),
+		config.WithExecutorParams(map[string]any{consts.ParamMaxPointsPerSecond: 100}),
+	))
+	require.NoError(t, err)
+	require.IsType(t, &throttledExecutor{}, exec)
+	assert.IsType(t, &repeatExecutor{}, exec.(*throttledExecutor).executor, "the budget spans every iteration")
+}
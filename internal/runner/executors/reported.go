@@ -0,0 +1,22 @@
+package executors
+
+import (
+	"context"
+
+	"github.com/neonmei/szgen/internal/runner"
+)
+
+// reportedExecutor tracks what the tasks of any strategy record in a run report,
+// repeated and retried runs of a task adding up.
+type reportedExecutor struct {
+	executor runner.Executor
+	report   *runner.Report
+}
+
+func (e *reportedExecutor) Execute(ctx context.Context, tasks []runner.Task) error {
+	return e.executor.Execute(ctx, e.report.Track(tasks))
+}
+
+func NewReported(executor runner.Executor, report *runner.Report) *reportedExecutor {
+	return &reportedExecutor{executor: executor, report: report}
+}
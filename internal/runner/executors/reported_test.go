@@ -0,0 +1,31 @@
+package executors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/runner"
+	"github.com/neonmei/szgen/internal/runner/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportedExecutor_Execute(t *testing.T) {
+	report := runner.NewReport()
+	exec, err := New(config.NewExecutorConfig(config.WithRepeat(2, 0)), WithReport(report))
+	require.NoError(t, err)
+
+	task := &mocks.MockTask{
+		NameVal: "task",
+		ExecuteFunc: func(ctx context.Context) error {
+			return runner.Throttle(ctx, 5)
+		},
+	}
+	require.NoError(t, exec.Execute(context.Background(), []runner.Task{task}))
+
+	summary := report.Summary()
+	require.Len(t, summary.Tasks, 1)
+	assert.Equal(t, 2, summary.Tasks[0].Runs, "every iteration is reported")
+	assert.Equal(t, int64(10), summary.Tasks[0].Points)
+}
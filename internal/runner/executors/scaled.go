@@ -0,0 +1,25 @@
+package executors
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/neonmei/szgen/internal/runner"
+	"github.com/neonmei/szgen/internal/runner/clock"
+)
+
+// scaledExecutor runs the tasks of any strategy on a clock going faster, or slower, than
+// the wall clock, compressing schedules, phases and executor stages alike.
+type scaledExecutor struct {
+	executor runner.Executor
+	scale    float64
+}
+
+func (e *scaledExecutor) Execute(ctx context.Context, tasks []runner.Task) error {
+	slog.Info("Time scaled", "scale", e.scale)
+	return e.executor.Execute(clock.With(ctx, clock.NewScaled(e.scale)), tasks)
+}
+
+func NewScaled(executor runner.Executor, scale float64) *scaledExecutor {
+	return &scaledExecutor{executor: executor, scale: scale}
+}
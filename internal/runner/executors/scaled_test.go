@@ -0,0 +1,41 @@
+package executors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/runner"
+	"github.com/neonmei/szgen/internal/runner/clock"
+	"github.com/neonmei/szgen/internal/runner/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+)
+
+func TestScaledExecutor_Execute(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	task := &mocks.MockTask{
+		NameVal: "task",
+		ExecuteFunc: func(ctx context.Context) error {
+			<-clock.From(ctx).After(10 * time.Second)
+			return nil
+		},
+	}
+
+	start := time.Now()
+	require.NoError(t, NewScaled(NewSerial(config.FailurePolicy{}), 1000).Execute(context.Background(), []runner.Task{task}))
+	assert.Less(t, time.Since(start), time.Second, "ten seconds take ten milliseconds at 1000x")
+}
+
+func TestNew_Scaled(t *testing.T) {
+	exec, err := New(config.NewExecutorConfig(config.WithTimeScale(60)))
+	require.NoError(t, err)
+	assert.IsType(t, &scaledExecutor{}, exec)
+
+	exec, err = New(config.NewExecutorConfig(config.WithTimeScale(1)))
+	require.NoError(t, err)
+	assert.IsType(t, &serialExecutor{}, exec, "the wall clock needs no scaling")
+}
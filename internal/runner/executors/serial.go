@@ -2,28 +2,37 @@ package executors
 
 import (
 	"context"
-	"fmt"
 	"log/slog"
 
+	"github.com/neonmei/szgen/internal/config"
 	"github.com/neonmei/szgen/internal/runner"
 )
 
-type serialExecutor struct{}
+type serialExecutor struct {
+	policy config.FailurePolicy
+}
 
 func (e *serialExecutor) Execute(ctx context.Context, tasks []runner.Task) error {
-	for i, task := range tasks {
+	failures := newFailures(e.policy)
+
+	for _, task := range tasks {
 		if ctx.Err() != nil {
 			return ctx.Err()
 		}
-		if err := task.Execute(ctx); err != nil {
-			return fmt.Errorf("task %d aborted: %w", i+1, err)
+		if err := failures.run(ctx, task); err != nil {
+			return err
 		}
 	}
 
+	if err := failures.err(); err != nil {
+		slog.Error("serial executor failed", "error", err)
+		return err
+	}
+
 	slog.Info("serial executor finished", "tasks", len(tasks))
 	return nil
 }
 
-func NewSerial() *serialExecutor {
-	return &serialExecutor{}
+func NewSerial(policy config.FailurePolicy) *serialExecutor {
+	return &serialExecutor{policy: policy}
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/neonmei/szgen/internal/config"
 	"github.com/neonmei/szgen/internal/runner"
 	"github.com/neonmei/szgen/internal/runner/mocks"
 	"github.com/stretchr/testify/assert"
@@ -58,7 +59,7 @@ func TestSerialExecutor_Execute(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			exec := NewSerial()
+			exec := NewSerial(config.FailurePolicy{})
 			err := exec.Execute(context.Background(), tt.tasks)
 			if tt.wantErr {
 				assert.Error(t, err)
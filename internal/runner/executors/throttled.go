@@ -0,0 +1,41 @@
+package executors
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/neonmei/szgen/internal/runner"
+)
+
+// throttledExecutor shares a limiter between the tasks of any strategy, capping the
+// aggregate rate of data points, and reports the throughput achieved once done.
+type throttledExecutor struct {
+	executor runner.Executor
+	limiter  *runner.Limiter
+}
+
+func (e *throttledExecutor) Execute(ctx context.Context, tasks []runner.Task) error {
+	start := time.Now()
+	err := e.executor.Execute(runner.WithLimiter(ctx, e.limiter), tasks)
+	elapsed := time.Since(start)
+
+	points := e.limiter.Points()
+	achieved := float64(points) / max(elapsed.Seconds(), math.SmallestNonzeroFloat64)
+	slog.Info("Throughput",
+		"target", e.limiter.Rate(),
+		"achieved", math.Round(achieved*100)/100,
+		"points", points,
+		"elapsed", elapsed.Round(time.Millisecond),
+	)
+
+	return err
+}
+
+func NewThrottled(executor runner.Executor, pointsPerSecond float64) *throttledExecutor {
+	return &throttledExecutor{
+		executor: executor,
+		limiter:  runner.NewLimiter(pointsPerSecond),
+	}
+}
@@ -0,0 +1,62 @@
+package executors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/consts"
+	"github.com/neonmei/szgen/internal/runner"
+	"github.com/neonmei/szgen/internal/runner/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+)
+
+func TestThrottledExecutor_Execute(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	// every task records 20 points as fast as the budget allows
+	task := func() runner.Task {
+		return &mocks.MockTask{
+			NameVal: "task",
+			ExecuteFunc: func(ctx context.Context) error {
+				for range 20 {
+					if err := runner.Throttle(ctx, 1); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		}
+	}
+
+	for _, strategy := range []string{consts.ExecutorStrategySerial, consts.ExecutorStrategyConcurrent} {
+		t.Run(strategy, func(t *testing.T) {
+			exec, err := New(config.NewExecutorConfig(
+				config.WithExecutorStrategy(strategy),
+				config.WithExecutorParams(map[string]any{consts.ParamMaxPointsPerSecond: 500}),
+			))
+			require.NoError(t, err)
+			require.IsType(t, &throttledExecutor{}, exec)
+
+			// 500 points pass as a burst, the 100 left take 200ms
+			tasks := make([]runner.Task, 30)
+			for i := range tasks {
+				tasks[i] = task()
+			}
+
+			start := time.Now()
+			require.NoError(t, exec.Execute(context.Background(), tasks))
+			assert.GreaterOrEqual(t, time.Since(start), 180*time.Millisecond)
+			assert.Equal(t, int64(600), exec.(*throttledExecutor).limiter.Points())
+		})
+	}
+}
+
+func TestNew_Unthrottled(t *testing.T) {
+	exec, err := New(config.NewExecutorConfig())
+	require.NoError(t, err)
+	assert.IsType(t, &serialExecutor{}, exec)
+}
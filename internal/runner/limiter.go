@@ -0,0 +1,84 @@
+package runner
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Limiter is a token bucket shared by every task of a run, capping the aggregate rate of
+// data points. The bucket holds up to a second worth of points so short bursts pass.
+type Limiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+
+	points atomic.Int64
+}
+
+type limiterKey struct{}
+
+func NewLimiter(pointsPerSecond float64) *Limiter {
+	burst := max(pointsPerSecond, 1)
+	return &Limiter{
+		rate:   pointsPerSecond,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until n points fit in the budget. Points are reserved right away, so
+// concurrent callers queue up in arrival order.
+func (l *Limiter) Wait(ctx context.Context, n int) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens -= float64(n)
+	deficit := -l.tokens
+	l.mu.Unlock()
+
+	if deficit > 0 {
+		select {
+		case <-time.After(time.Duration(deficit / l.rate * float64(time.Second))):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	l.points.Add(int64(n))
+	return nil
+}
+
+// Rate is the target amount of points per second.
+func (l *Limiter) Rate() float64 {
+	return l.rate
+}
+
+// Points is the amount of points let through so far.
+func (l *Limiter) Points() int64 {
+	return l.points.Load()
+}
+
+// WithLimiter makes tasks executed with the returned context share a limiter.
+func WithLimiter(ctx context.Context, limiter *Limiter) context.Context {
+	return context.WithValue(ctx, limiterKey{}, limiter)
+}
+
+// Throttle is consulted by tasks before recording n data points, it waits for the
+// limiter of the run if there is one and counts the points of benchmarked and reported tasks.
+func Throttle(ctx context.Context, n int) error {
+	if limiter, ok := ctx.Value(limiterKey{}).(*Limiter); ok {
+		if err := limiter.Wait(ctx, n); err != nil {
+			return err
+		}
+	}
+
+	countPoints(ctx, n)
+	reportPoints(ctx, n)
+	return nil
+}
@@ -0,0 +1,54 @@
+package runner
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter_Wait(t *testing.T) {
+	limiter := NewLimiter(1000)
+	ctx := context.Background()
+
+	start := time.Now()
+	require.NoError(t, limiter.Wait(ctx, 1000))
+	assert.Less(t, time.Since(start), 20*time.Millisecond, "a second worth of points passes right away")
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, limiter.Wait(ctx, 10))
+		}()
+	}
+	wg.Wait()
+
+	assert.GreaterOrEqual(t, time.Since(start), 45*time.Millisecond, "concurrent callers share the budget")
+	assert.Equal(t, int64(1050), limiter.Points())
+	assert.Equal(t, 1000.0, limiter.Rate())
+}
+
+func TestLimiter_WaitCanceled(t *testing.T) {
+	limiter := NewLimiter(1)
+	require.NoError(t, limiter.Wait(context.Background(), 1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, limiter.Wait(ctx, 1), context.DeadlineExceeded)
+	assert.Equal(t, int64(1), limiter.Points())
+}
+
+func TestThrottle(t *testing.T) {
+	assert.NoError(t, Throttle(context.Background(), 1_000_000), "no limiter, no waiting")
+
+	limiter := NewLimiter(10)
+	ctx := WithLimiter(context.Background(), limiter)
+	require.NoError(t, Throttle(ctx, 3))
+	assert.Equal(t, int64(3), limiter.Points())
+}
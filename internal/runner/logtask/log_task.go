@@ -0,0 +1,161 @@
+package logtask
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"log/slog"
+	"math/rand/v2"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/consts"
+	"github.com/neonmei/szgen/internal/generator"
+	"github.com/neonmei/szgen/internal/runner"
+	"github.com/neonmei/szgen/internal/runner/clock"
+	"github.com/neonmei/szgen/internal/runner/progress"
+	"github.com/neonmei/szgen/internal/runner/schedule"
+	"go.opentelemetry.io/otel/log"
+)
+
+// bodyData is available to log body templates.
+type bodyData struct {
+	Name     string
+	Index    int
+	Tick     int
+	Severity string
+}
+
+// random streams derived from the seed, kept apart so e.g. changing the severities
+// does not change the volume.
+const (
+	streamVolume uint64 = iota + 1
+	streamSeverities
+)
+
+type logTask struct {
+	seed        uint64
+	sources     map[uint64]*rand.PCG
+	logger      log.Logger
+	volume      generator.ValueGenerator[int64]
+	genInterval time.Duration
+	taskName    string
+	count       int
+	body        *template.Template
+	severities  *severityPicker
+	attrs       []log.KeyValue
+}
+
+func (lt *logTask) Name() string {
+	return lt.taskName
+}
+
+func (lt *logTask) Execute(ctx context.Context) error {
+	slog.Info("Log task running", "log", lt.taskName, "interval", lt.genInterval)
+
+	sched := schedule.New(lt.genInterval)
+	sched.Start(clock.From(ctx).Now())
+
+	// every execution of an iteration, retries included, emits the same records
+	seed := lt.seed
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+	for stream, src := range lt.sources {
+		runner.SeedStream(src, seed, stream, runner.Iteration(ctx))
+	}
+
+	next, stop := iter.Pull(iter.Seq[int64](lt.volume))
+	defer stop()
+	progress.Begin(ctx, lt.count)
+
+	index := 0
+	for tick := 0; ; tick++ {
+		if err := sched.Wait(ctx); err != nil {
+			return err
+		}
+
+		records, ok := next()
+		if !ok {
+			slog.Info("Completed execution", "log", lt.taskName, "records", index)
+			return nil
+		}
+
+		for range records {
+			if err := runner.Throttle(ctx, 1); err != nil {
+				return err
+			}
+
+			if err := lt.emit(ctx, index, tick); err != nil {
+				return err
+			}
+			index++
+		}
+		progress.Tick(ctx, float64(records))
+	}
+}
+
+func (lt *logTask) emit(ctx context.Context, index, tick int) error {
+	sev := lt.severities.pick()
+
+	var body strings.Builder
+	data := bodyData{Name: lt.taskName, Index: index, Tick: tick, Severity: sev.name}
+	if err := lt.body.Execute(&body, data); err != nil {
+		return fmt.Errorf("render log body: %w", err)
+	}
+
+	now := clock.From(ctx).Now()
+	var record log.Record
+	record.SetTimestamp(now)
+	record.SetObservedTimestamp(now)
+	record.SetSeverity(sev.number)
+	record.SetSeverityText(sev.text)
+	record.SetBody(log.StringValue(body.String()))
+	record.AddAttributes(lt.attrs...)
+
+	lt.logger.Emit(ctx, record)
+	return nil
+}
+
+// New creates a runnable task from model (file, cli, etc) configuration.
+// The context here allows cancelling generation at the producer (i.e: value generator) level.
+func New(ctx context.Context, lTask config.LogTask, opts ...Option) (runner.Task, error) {
+	if err := lTask.Validate(); err != nil {
+		return nil, err
+	}
+
+	o := newOptions(opts...)
+	sources := map[uint64]*rand.PCG{streamVolume: {}, streamSeverities: {}}
+
+	volume, err := generator.New[int64](ctx, lTask.Generator, lTask.Value, lTask.Count,
+		generator.WithRand(rand.New(sources[streamVolume])))
+	if err != nil {
+		return nil, fmt.Errorf("create log volume iterator: %w", err)
+	}
+
+	body, err := template.New(lTask.Name).Parse(lTask.Body)
+	if err != nil {
+		return nil, fmt.Errorf("parse log body: %w", err)
+	}
+
+	attrs := runner.ParseAttributes(lTask.Attributes)
+	logAttrs := make([]log.KeyValue, 0, len(attrs))
+	for _, attr := range attrs {
+		logAttrs = append(logAttrs, log.KeyValueFromAttribute(attr))
+	}
+
+	return &logTask{
+		seed:        lTask.Seed,
+		sources:     sources,
+		logger:      o.loggerProvider.Logger(consts.DefaultLoggerName),
+		volume:      volume,
+		genInterval: lTask.Rate,
+		taskName:    lTask.Name,
+		count:       lTask.Count,
+		body:        body,
+		severities:  newSeverityPicker(lTask.Severities, rand.New(sources[streamSeverities])),
+		attrs:       logAttrs,
+	}, nil
+}
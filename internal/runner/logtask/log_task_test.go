@@ -0,0 +1,151 @@
+package logtask
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/consts"
+	"github.com/neonmei/szgen/internal/runner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+type recordingExporter struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+func (e *recordingExporter) Export(_ context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, r := range records {
+		e.records = append(e.records, r.Clone())
+	}
+	return nil
+}
+
+func (e *recordingExporter) Shutdown(context.Context) error   { return nil }
+func (e *recordingExporter) ForceFlush(context.Context) error { return nil }
+
+func newRecorder() (*recordingExporter, *sdklog.LoggerProvider) {
+	exporter := &recordingExporter{}
+	return exporter, sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+}
+
+func TestLogTask_Execute(t *testing.T) {
+	t.Run("emit records per tick", func(t *testing.T) {
+		exporter, lp := newRecorder()
+		cfg := config.NewLogTask(
+			config.WithLogName("checkout"),
+			config.WithLogRate(time.Millisecond),
+			config.WithLogCount(3),
+			config.WithLogValue("2"),
+			config.WithBody("{{ .Name }} record {{ .Index }} tick {{ .Tick }} {{ .Severity }}"),
+			config.WithLogAttributes(map[string]any{"env": "test"}),
+		)
+
+		task, err := New(context.Background(), *cfg, WithLoggerProvider(lp))
+		require.NoError(t, err)
+		assert.Equal(t, "checkout", task.Name())
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		require.NoError(t, task.Execute(ctx))
+
+		require.Len(t, exporter.records, 6)
+		assert.Equal(t, "checkout record 0 tick 0 info", exporter.records[0].Body().AsString())
+		assert.Equal(t, "checkout record 5 tick 2 info", exporter.records[5].Body().AsString())
+
+		for _, r := range exporter.records {
+			assert.Equal(t, log.SeverityInfo, r.Severity())
+			assert.Equal(t, "INFO", r.SeverityText())
+			assert.Equal(t, 1, r.AttributesLen())
+			r.WalkAttributes(func(kv log.KeyValue) bool {
+				assert.Equal(t, "env", kv.Key)
+				assert.Equal(t, "test", kv.Value.AsString())
+				return true
+			})
+		}
+	})
+
+	t.Run("severity distribution", func(t *testing.T) {
+		exporter, lp := newRecorder()
+		cfg := config.NewLogTask(
+			config.WithLogRate(time.Millisecond),
+			config.WithLogCount(1),
+			config.WithLogValue("1000"),
+			config.WithSeverities(map[string]float64{
+				consts.SeverityInfo:  0.5,
+				consts.SeverityError: 0.5,
+				consts.SeverityDebug: 0,
+			}),
+		)
+
+		task, err := New(context.Background(), *cfg, WithLoggerProvider(lp))
+		require.NoError(t, err)
+		require.NoError(t, task.Execute(context.Background()))
+
+		counts := make(map[log.Severity]int)
+		for _, r := range exporter.records {
+			counts[r.Severity()]++
+		}
+
+		assert.Len(t, exporter.records, 1000)
+		assert.Zero(t, counts[log.SeverityDebug])
+		assert.InDelta(t, 500, counts[log.SeverityInfo], 150)
+		assert.InDelta(t, 500, counts[log.SeverityError], 150)
+	})
+
+	t.Run("stop on context cancellation", func(t *testing.T) {
+		_, lp := newRecorder()
+		cfg := config.NewLogTask(config.WithLogCount(0))
+
+		task, err := New(context.Background(), *cfg, WithLoggerProvider(lp))
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, task.Execute(ctx), context.DeadlineExceeded)
+	})
+}
+
+func TestLogTask_Seed(t *testing.T) {
+	exporter, lp := newRecorder()
+	cfg := config.NewLogTask(
+		config.WithLogRate(time.Millisecond),
+		config.WithLogCount(5),
+		config.WithLogGenerator(consts.GeneratorRandom),
+		config.WithLogValue("1,50"),
+		config.WithSeverities(map[string]float64{consts.SeverityInfo: 1, consts.SeverityWarn: 1, consts.SeverityError: 1}),
+		config.WithLogSeed(42),
+	)
+
+	task, err := New(context.Background(), *cfg, WithLoggerProvider(lp))
+	require.NoError(t, err)
+
+	run := func(iteration int) []log.Severity {
+		before := len(exporter.records)
+		require.NoError(t, task.Execute(runner.WithIteration(context.Background(), iteration)))
+
+		severities := make([]log.Severity, 0, len(exporter.records)-before)
+		for _, r := range exporter.records[before:] {
+			severities = append(severities, r.Severity())
+		}
+		return severities
+	}
+
+	first := run(1)
+	assert.Equal(t, first, run(1), "executing again replays the iteration")
+	assert.NotEqual(t, first, run(2), "iterations draw other records")
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	cfg := config.NewLogTask(config.WithBody("{{ .Index"))
+	_, err := New(context.Background(), *cfg)
+	assert.Error(t, err)
+}
@@ -0,0 +1,34 @@
+package logtask
+
+import (
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+)
+
+type (
+	Option  func(*options)
+	options struct {
+		loggerProvider log.LoggerProvider
+	}
+)
+
+func newOptions(opts ...Option) options {
+	o := options{
+		loggerProvider: global.GetLoggerProvider(),
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// WithLoggerProvider emits the task records through a specific provider instead of the global one.
+func WithLoggerProvider(lp log.LoggerProvider) Option {
+	return func(o *options) {
+		if lp != nil {
+			o.loggerProvider = lp
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package logtask
+
+import (
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"strings"
+
+	"github.com/neonmei/szgen/internal/consts"
+	"go.opentelemetry.io/otel/log"
+)
+
+var severityNumbers = map[string]log.Severity{
+	consts.SeverityTrace: log.SeverityTrace,
+	consts.SeverityDebug: log.SeverityDebug,
+	consts.SeverityInfo:  log.SeverityInfo,
+	consts.SeverityWarn:  log.SeverityWarn,
+	consts.SeverityError: log.SeverityError,
+	consts.SeverityFatal: log.SeverityFatal,
+}
+
+type severity struct {
+	name   string
+	text   string
+	number log.Severity
+}
+
+// severityPicker draws severities following a weighted distribution.
+type severityPicker struct {
+	rand       *rand.Rand
+	severities []severity
+	cumulative []float64
+}
+
+func newSeverityPicker(weights map[string]float64, r *rand.Rand) *severityPicker {
+	if len(weights) == 0 {
+		weights = map[string]float64{consts.DefaultSeverity: 1}
+	}
+
+	// sorted so the distribution does not depend on map iteration order
+	names := slices.Sorted(maps.Keys(weights))
+
+	sp := &severityPicker{rand: r}
+	var total float64
+	for _, name := range names {
+		if weights[name] <= 0 {
+			continue
+		}
+
+		total += weights[name]
+		sp.severities = append(sp.severities, severity{
+			name:   name,
+			text:   strings.ToUpper(name),
+			number: severityNumbers[name],
+		})
+		sp.cumulative = append(sp.cumulative, total)
+	}
+
+	for i := range sp.cumulative {
+		sp.cumulative[i] /= total
+	}
+
+	return sp
+}
+
+func (sp *severityPicker) pick() severity {
+	if len(sp.severities) == 1 {
+		return sp.severities[0]
+	}
+
+	r := sp.rand.Float64()
+	for i, c := range sp.cumulative {
+		if r < c {
+			return sp.severities[i]
+		}
+	}
+
+	return sp.severities[len(sp.severities)-1]
+}
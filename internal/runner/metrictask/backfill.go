@@ -0,0 +1,91 @@
+package metrictask
+
+import (
+	"context"
+	"iter"
+	"time"
+
+	"github.com/neonmei/szgen/internal/runner"
+	"github.com/neonmei/szgen/internal/runner/schedule"
+)
+
+// Backfiller is a task that can also record its points over a virtual time range, as
+// fast as possible rather than waiting for them to be due.
+type Backfiller interface {
+	runner.Task
+
+	// StartAt lays the points of the task out from a virtual start time.
+	StartAt(from time.Time)
+
+	// RecordUntil records every point due before until, returning the amount recorded
+	// and whether the task has points left.
+	RecordUntil(ctx context.Context, until time.Time) (int, bool)
+
+	// Stop releases the generators of the task, StartAt pulls them again from the beginning.
+	Stop()
+}
+
+// backfill is the position of a task within its virtual range: the next value and when
+// it's due are kept while they fall beyond the current window.
+type backfill[T int64 | float64] struct {
+	next    func() (T, bool)
+	stop    func()
+	value   T
+	due     time.Time
+	pending bool
+}
+
+func (im *metricTask[T]) StartAt(from time.Time) {
+	im.Stop()
+
+	next, stop := iter.Pull(iter.Seq[T](im.genIter))
+	im.backfill = &backfill[T]{next: next, stop: stop}
+
+	if im.schedule == nil {
+		im.schedule = schedule.New(im.genInterval)
+	}
+	im.schedule.Start(from.Add(im.delay))
+}
+
+func (im *metricTask[T]) RecordUntil(ctx context.Context, until time.Time) (int, bool) {
+	b := im.backfill
+	if b == nil {
+		return 0, false
+	}
+
+	recorded := 0
+	for ctx.Err() == nil {
+		if !b.pending {
+			value, ok := b.next()
+			if !ok {
+				return recorded, false
+			}
+			b.value, b.due, b.pending = value, im.schedule.Next(), true
+		}
+
+		if !b.due.Before(until) {
+			return recorded, true
+		}
+
+		im.recorder(ctx, b.value)
+		b.pending = false
+		recorded++
+	}
+
+	return recorded, false
+}
+
+func (im *metricTask[T]) Stop() {
+	if im.backfill != nil {
+		im.backfill.stop()
+		im.backfill = nil
+	}
+
+	if im.schedule != nil {
+		im.schedule.Stop()
+	}
+
+	if im.release != nil {
+		im.release()
+	}
+}
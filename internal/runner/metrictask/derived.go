@@ -0,0 +1,88 @@
+package metrictask
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/consts"
+	"github.com/neonmei/szgen/internal/expression"
+	"github.com/neonmei/szgen/internal/runner"
+)
+
+// Recorder records a value computed from the value its source task just recorded.
+type Recorder func(ctx context.Context, value float64)
+
+// NewDerived creates the recorder of a derived task. It has no schedule of its own: every
+// value recorded by the source is fed through the task expression, and the result is
+// passed on to the tasks derived from this one in turn.
+func NewDerived(cfg config.MetricTask, derived []Recorder, opts ...Option) (Recorder, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	if cfg.DerivedFrom == "" {
+		return nil, fmt.Errorf("metric %q: not a derived metric", cfg.Name)
+	}
+
+	expr, err := expression.Parse(cfg.Expression)
+	if err != nil {
+		return nil, fmt.Errorf("metric %q: %w", cfg.Name, err)
+	}
+
+	o := newOptions(opts...)
+	meter := o.meterProvider.Meter(consts.DefaultMeterName)
+	attr := runner.ParseAttributes(cfg.Attributes)
+	series := runner.NewSeries(cfg.Name, cfg.Kind, attr)
+
+	// record returns the value as recorded, int64 metrics being rounded, so derived
+	// metrics further down the chain agree with what was exported
+	var record func(ctx context.Context, v float64) float64
+	switch cfg.Type {
+	case consts.ValueTypeInt64:
+		rec, err := newInt64Recorder(meter, cfg, attr)
+		if err != nil {
+			return nil, err
+		}
+		rec = observed(rec, series)
+		record = func(ctx context.Context, v float64) float64 {
+			rounded := int64(math.Round(v))
+			rec(ctx, rounded)
+			return float64(rounded)
+		}
+	case consts.ValueTypeFloat64:
+		rec, err := newFloat64Recorder(meter, cfg, attr)
+		if err != nil {
+			return nil, err
+		}
+		rec = observed(rec, series)
+		record = func(ctx context.Context, v float64) float64 {
+			rec(ctx, v)
+			return v
+		}
+	default:
+		return nil, fmt.Errorf("unsupported metric type: %s", cfg.Type)
+	}
+
+	return func(ctx context.Context, v float64) {
+		value := record(ctx, expr.Eval(v))
+		for _, d := range derived {
+			d(ctx, value)
+		}
+	}, nil
+}
+
+// withDerived feeds every value a task records to the tasks derived from it.
+func withDerived[T int64 | float64](recorder valueRecorder[T], derived []Recorder) valueRecorder[T] {
+	if len(derived) == 0 {
+		return recorder
+	}
+
+	return func(ctx context.Context, v T) {
+		recorder(ctx, v)
+		for _, d := range derived {
+			d(ctx, float64(v))
+		}
+	}
+}
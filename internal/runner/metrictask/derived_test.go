@@ -0,0 +1,102 @@
+package metrictask
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/consts"
+	"github.com/neonmei/szgen/internal/runner"
+	"github.com/neonmei/szgen/internal/runner/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestNewDerived(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	bytesCfg := config.NewMetricTask(config.WithName("error.bytes"), config.WithKind(consts.MetricTypeCounter),
+		config.WithType(consts.ValueTypeFloat64), config.WithDerivedFrom("errors", "value * 0.5"))
+	errorsCfg := config.NewMetricTask(config.WithName("errors"), config.WithKind(consts.MetricTypeCounter),
+		config.WithType(consts.ValueTypeInt64), config.WithDerivedFrom("requests", "value * 0.1"))
+
+	var chained []float64
+	bytesRec, err := NewDerived(*bytesCfg, []Recorder{func(_ context.Context, v float64) { chained = append(chained, v) }}, WithMeterProvider(mp))
+	require.NoError(t, err)
+
+	errorsRec, err := NewDerived(*errorsCfg, []Recorder{bytesRec}, WithMeterProvider(mp))
+	require.NoError(t, err)
+
+	errorsRec(context.Background(), 57)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	sums := make(map[string]any)
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		switch data := m.Data.(type) {
+		case metricdata.Sum[int64]:
+			sums[m.Name] = data.DataPoints[0].Value
+		case metricdata.Sum[float64]:
+			sums[m.Name] = data.DataPoints[0].Value
+		}
+	}
+
+	assert.Equal(t, int64(6), sums["errors"], "int64 derived metrics are rounded")
+	assert.Equal(t, 3.0, sums["error.bytes"], "chained metrics derive from the recorded value")
+	assert.Equal(t, []float64{3}, chained)
+}
+
+func TestNewDerived_Errors(t *testing.T) {
+	_, err := NewDerived(*config.NewMetricTask(), nil)
+	assert.ErrorContains(t, err, "not a derived metric")
+
+	_, err = New(context.Background(), *config.NewMetricTask(config.WithDerivedFrom("requests", "value")))
+	assert.ErrorContains(t, err, "recorded by their source")
+}
+
+func TestWithDerived(t *testing.T) {
+	var recorded []int64
+	var derived []float64
+
+	recorder := withDerived[int64](
+		func(_ context.Context, v int64) { recorded = append(recorded, v) },
+		[]Recorder{func(_ context.Context, v float64) { derived = append(derived, v) }},
+	)
+
+	recorder(context.Background(), 4)
+	assert.Equal(t, []int64{4}, recorded)
+	assert.Equal(t, []float64{4}, derived)
+}
+
+func TestNew_ReportsSeries(t *testing.T) {
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewManualReader()))
+
+	doubledCfg := config.NewMetricTask(config.WithName("requests.doubled"), config.WithKind(consts.MetricTypeGauge),
+		config.WithType(consts.ValueTypeFloat64), config.WithDerivedFrom("requests", "value * 2"))
+	doubled, err := NewDerived(*doubledCfg, nil, WithMeterProvider(mp))
+	require.NoError(t, err)
+
+	task, err := New(context.Background(), *config.NewMetricTask(
+		config.WithName("requests"), config.WithKind(consts.MetricTypeCounter), config.WithType(consts.ValueTypeInt64),
+		config.WithGenerator(consts.GeneratorSequence), config.WithValue("1,2,3"), config.WithCount(3), config.WithRate(time.Second),
+	), WithMeterProvider(mp), WithDerived(doubled))
+	require.NoError(t, err)
+
+	report := runner.NewReport()
+	tasks := report.Track([]runner.Task{task})
+	require.NoError(t, tasks[0].Execute(clock.With(context.Background(), clock.NewInstant(time.Now()))))
+
+	summary := report.Summary().Tasks[0]
+	assert.Equal(t, int64(6), summary.Points, "derived points count with their source")
+	require.Len(t, summary.Series, 2)
+	assert.Equal(t, "requests", summary.Series[0].Metric)
+	assert.Equal(t, 6.0, *summary.Series[0].ExpectedTotal)
+	assert.Equal(t, "requests.doubled", summary.Series[1].Metric)
+	assert.Equal(t, 6.0, summary.Series[1].Last)
+	assert.Nil(t, summary.Series[1].ExpectedTotal, "gauges have no total")
+}
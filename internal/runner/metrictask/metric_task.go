@@ -3,6 +3,7 @@ package metrictask
 import (
 	"context"
 	"fmt"
+	"iter"
 	"log/slog"
 	"time"
 
@@ -10,6 +11,10 @@ import (
 	"github.com/neonmei/szgen/internal/consts"
 	"github.com/neonmei/szgen/internal/generator"
 	"github.com/neonmei/szgen/internal/runner"
+	"github.com/neonmei/szgen/internal/runner/clock"
+	"github.com/neonmei/szgen/internal/runner/phases"
+	"github.com/neonmei/szgen/internal/runner/progress"
+	"github.com/neonmei/szgen/internal/runner/schedule"
 )
 
 type valueRecorder[T int64 | float64] func(context.Context, T)
@@ -18,7 +23,26 @@ type metricTask[T int64 | float64] struct {
 	recorder    valueRecorder[T]
 	genIter     generator.ValueGenerator[T]
 	genInterval time.Duration
+	delay       time.Duration
 	taskName    string
+
+	// schedule times the data points, by default one per interval starting one interval
+	// after the task.
+	schedule *schedule.Schedule
+
+	// release frees the resources held by the recorder once the task is done.
+	release func()
+
+	// count is the amount of data points of a run.
+	count int
+
+	// timeline switches the generator of the task as phases go by, phaseIter builds the
+	// generator of a phase for the data points left.
+	timeline  *phases.Timeline
+	phaseIter func(phase, count int) (generator.ValueGenerator[T], error)
+
+	// backfill holds the progress of the task through a virtual time range.
+	backfill *backfill[T]
 }
 
 func (im *metricTask[T]) Name() string {
@@ -26,40 +50,136 @@ func (im *metricTask[T]) Name() string {
 }
 
 func (im *metricTask[T]) Execute(ctx context.Context) error {
-	slog.Info("Iterator task running", "metric", im.taskName, "interval", im.genInterval)
+	if im.release != nil {
+		defer im.release()
+	}
 
-	ticker := time.NewTicker(im.genInterval)
-	defer ticker.Stop()
+	c := clock.From(ctx)
+	if im.delay > 0 {
+		slog.Debug("Delaying task", "metric", im.taskName, "delay", im.delay)
 
-	for value := range im.genIter {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		case <-ticker.C:
-			im.recorder(ctx, value)
-			slog.Debug("Recorded data point",
-				"metric", im.taskName,
-				"value", value,
-			)
+		case <-c.After(im.delay):
+		}
+	}
+
+	slog.Info("Iterator task running", "metric", im.taskName, "interval", im.genInterval)
+
+	if im.schedule == nil {
+		im.schedule = schedule.New(im.genInterval)
+	}
+	im.schedule.Start(c.Now())
+	defer im.schedule.Stop()
+	progress.Begin(ctx, im.count)
+
+	if im.timeline != nil {
+		if err := im.executePhased(ctx); err != nil {
+			return err
+		}
+
+		slog.Info("Completed execution", "metric", im.taskName)
+		return nil
+	}
+
+	for value := range im.genIter {
+		if err := im.schedule.Wait(ctx); err != nil {
+			return err
+		}
+
+		if err := im.record(ctx, value); err != nil {
+			return err
 		}
+
+		progress.Tick(ctx, float64(value))
+		slog.Debug("Recorded data point",
+			"metric", im.taskName,
+			"value", value,
+		)
 	}
 
 	slog.Info("Completed execution", "metric", im.taskName)
 	return nil
 }
 
+// executePhased records like Execute, rebuilding the generator whenever the active phase
+// changes. The value drawn before the switch is discarded in favour of the new generator.
+func (im *metricTask[T]) executePhased(ctx context.Context) error {
+	phase := im.timeline.Current()
+	gen, err := im.phaseIter(phase, im.count)
+	if err != nil {
+		return err
+	}
+
+	next, stop := iter.Pull(iter.Seq[T](gen))
+	defer func() { stop() }()
+
+	for recorded := 0; ; recorded++ {
+		value, ok := next()
+		if !ok {
+			return nil
+		}
+
+		if err := im.schedule.Wait(ctx); err != nil {
+			return err
+		}
+
+		if current := im.timeline.Current(); current != phase {
+			phase = current
+			gen, err := im.phaseIter(phase, im.count-recorded)
+			if err != nil {
+				return err
+			}
+
+			stop()
+			next, stop = iter.Pull(iter.Seq[T](gen))
+			if value, ok = next(); !ok {
+				return nil
+			}
+		}
+
+		if err := im.record(ctx, value); err != nil {
+			return err
+		}
+
+		progress.Tick(ctx, float64(value))
+		slog.Debug("Recorded data point",
+			"metric", im.taskName,
+			"value", value,
+			"phase", phase,
+		)
+	}
+}
+
+// record records a tick and charges the points it made up to the budget of the run,
+// derived metrics and the observations of request-driven histograms included. Their
+// number is only known once recorded, so waiting for the budget delays the next tick.
+func (im *metricTask[T]) record(ctx context.Context, value T) error {
+	var points int
+	im.recorder(context.WithValue(ctx, pointsKey{}, &points), value)
+
+	return runner.Throttle(ctx, points)
+}
+
 // New creates a runnable task from model (file, cli, etc) configuration.
 // The context here allows cancelling generation at the producer (i.e: value generator) level.
-func New(ctx context.Context, mTask config.MetricTask) (runner.Task, error) {
+func New(ctx context.Context, mTask config.MetricTask, opts ...Option) (runner.Task, error) {
 	if err := mTask.Validate(); err != nil {
 		return nil, err
 	}
 
+	if mTask.DerivedFrom != "" {
+		return nil, fmt.Errorf("metric %q: derived metrics are recorded by their source, see NewDerived", mTask.Name)
+	}
+
+	o := newOptions(opts...)
+
 	switch mTask.Type {
 	case consts.ValueTypeInt64:
-		return newInstrument[int64](ctx, mTask)
+		return newInstrument[int64](ctx, mTask, o)
 	case consts.ValueTypeFloat64:
-		return newInstrument[float64](ctx, mTask)
+		return newInstrument[float64](ctx, mTask, o)
 	default:
 		return nil, fmt.Errorf("unsupported metric type: %s", mTask.Type)
 	}
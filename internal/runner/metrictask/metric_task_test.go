@@ -2,11 +2,16 @@ package metrictask
 
 import (
 	"context"
+	"slices"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/consts"
 	"github.com/neonmei/szgen/internal/generator"
+	"github.com/neonmei/szgen/internal/runner/clock"
+	"github.com/neonmei/szgen/internal/runner/phases"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -33,21 +38,40 @@ func TestMetricTask_Execute(t *testing.T) {
 
 		task := &metricTask[int64]{
 			taskName:    "test-task",
-			genInterval: 1 * time.Millisecond,
+			genInterval: time.Minute,
 			genIter:     generator.ValueGenerator[int64](genFunc),
 			recorder:    recorder,
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
-		defer cancel()
+		start := time.Now()
+		fake := clock.NewInstant(start)
 
-		err := task.Execute(ctx)
+		err := task.Execute(clock.With(context.Background(), fake))
 		require.NoError(t, err)
 
 		mu.Lock()
 		defer mu.Unlock()
 		assert.Len(t, recordedValues, 5)
 		assert.Equal(t, []int64{0, 1, 2, 3, 4}, recordedValues)
+		assert.Equal(t, start.Add(5*time.Minute), fake.Now(), "one point per interval")
+	})
+
+	t.Run("executing again starts the generator over", func(t *testing.T) {
+		gen, err := generator.New[int64](context.Background(), consts.GeneratorStep, "10,5", 3)
+		require.NoError(t, err)
+
+		var recordedValues []int64
+		task := &metricTask[int64]{
+			taskName:    "repeated-task",
+			genInterval: time.Second,
+			genIter:     gen,
+			recorder:    func(_ context.Context, val int64) { recordedValues = append(recordedValues, val) },
+		}
+
+		ctx := clock.With(context.Background(), clock.NewInstant(time.Now()))
+		require.NoError(t, task.Execute(ctx))
+		require.NoError(t, task.Execute(ctx))
+		assert.Equal(t, []int64{10, 15, 20, 10, 15, 20}, recordedValues)
 	})
 
 	t.Run("stop on context cancellation", func(t *testing.T) {
@@ -62,7 +86,14 @@ func TestMetricTask_Execute(t *testing.T) {
 			}
 		}
 
-		recorder := func(_ context.Context, _ int64) {}
+		ctx, cancel := context.WithCancel(clock.With(context.Background(), clock.NewInstant(time.Now())))
+
+		// Cancel once a few data points were recorded
+		recorder := func(_ context.Context, v int64) {
+			if v == 5 {
+				cancel()
+			}
+		}
 
 		task := &metricTask[int64]{
 			taskName:    "infinite-task",
@@ -71,18 +102,11 @@ func TestMetricTask_Execute(t *testing.T) {
 			recorder:    recorder,
 		}
 
-		ctx, cancel := context.WithCancel(context.Background())
-
-		// Run in goroutine
 		errChan := make(chan error)
 		go func() {
 			errChan <- task.Execute(ctx)
 		}()
 
-		// Let it run for a bit
-		time.Sleep(50 * time.Millisecond)
-		cancel()
-
 		select {
 		case err := <-errChan:
 			assert.ErrorIs(t, err, context.Canceled)
@@ -90,4 +114,119 @@ func TestMetricTask_Execute(t *testing.T) {
 			t.Fatal("task did not stop after context cancellation")
 		}
 	})
+
+	t.Run("delay postpones the first data point", func(t *testing.T) {
+		start := time.Now()
+		fake := clock.NewInstant(start)
+
+		var recordedAt time.Time
+		task := &metricTask[int64]{
+			taskName:    "delayed-task",
+			genInterval: time.Second,
+			delay:       time.Minute,
+			genIter:     generator.ValueGenerator[int64](func(yield func(int64) bool) { yield(1) }),
+			recorder:    func(_ context.Context, _ int64) { recordedAt = fake.Now() },
+		}
+
+		require.NoError(t, task.Execute(clock.With(context.Background(), fake)))
+		assert.Equal(t, start.Add(time.Minute+time.Second), recordedAt)
+	})
+
+	t.Run("cancelled while delayed", func(t *testing.T) {
+		task := &metricTask[int64]{
+			taskName:    "delayed-task",
+			genInterval: time.Millisecond,
+			delay:       time.Hour,
+			genIter:     generator.ValueGenerator[int64](func(yield func(int64) bool) { yield(1) }),
+			recorder:    func(_ context.Context, _ int64) { t.Fatal("recorded while delayed") },
+		}
+
+		ctx, cancel := context.WithCancel(clock.With(context.Background(), clock.NewFake(time.Now())))
+		cancel()
+
+		assert.ErrorIs(t, task.Execute(ctx), context.Canceled)
+	})
+}
+
+func TestIntercept(t *testing.T) {
+	type ctxKey struct{}
+
+	t.Run("nil interceptor keeps recorder", func(t *testing.T) {
+		var recorded []int64
+		recorder := intercept[int64](func(_ context.Context, v int64) { recorded = append(recorded, v) }, nil)
+
+		recorder(context.Background(), 7)
+		assert.Equal(t, []int64{7}, recorded)
+	})
+
+	t.Run("interceptor decorates context", func(t *testing.T) {
+		var seen []float64
+		var values []any
+		interceptor := func(ctx context.Context, v float64, record func(context.Context)) {
+			seen = append(seen, v)
+			record(context.WithValue(ctx, ctxKey{}, "request"))
+		}
+
+		recorder := intercept[int64](func(ctx context.Context, _ int64) {
+			values = append(values, ctx.Value(ctxKey{}))
+		}, interceptor)
+
+		recorder(context.Background(), 3)
+		recorder(context.Background(), 5)
+		assert.Equal(t, []float64{3, 5}, seen)
+		assert.Equal(t, []any{"request", "request"}, values)
+	})
+}
+
+func TestMetricTask_ExecutePhased(t *testing.T) {
+	timeline := phases.New([]config.PhaseConfig{
+		{Name: "normal", Duration: 40 * time.Millisecond},
+		{Name: "outage", Duration: 40 * time.Millisecond, Tasks: map[string]config.PhaseOverride{"errors": {Value: "100"}}},
+	})
+
+	cfg := config.MetricTask{Name: "errors", Kind: consts.MetricTypeGauge, Type: consts.ValueTypeInt64, Rate: config.Rate(10 * time.Millisecond), Count: 12, Value: "1", Generator: consts.GeneratorConstant}
+
+	var mu sync.Mutex
+	var recorded []int64
+	task, err := New(context.Background(), cfg,
+		WithTimeline(timeline),
+		WithRecordInterceptor(func(ctx context.Context, v float64, record func(context.Context)) {
+			mu.Lock()
+			defer mu.Unlock()
+			recorded = append(recorded, int64(v))
+		}),
+	)
+	require.NoError(t, err)
+
+	timeline.Start(context.Background())
+	require.NoError(t, task.Execute(context.Background()))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, recorded, 12)
+	assert.Equal(t, int64(1), recorded[0], "normal phase uses the task value")
+	assert.Contains(t, recorded, int64(100), "outage phase overrides the task value")
+	assert.Equal(t, int64(1), recorded[11], "tasks recover once phases are over")
+}
+
+func TestNew_InvalidPhaseValue(t *testing.T) {
+	timeline := phases.New([]config.PhaseConfig{
+		{Name: "outage", Duration: time.Minute, Tasks: map[string]config.PhaseOverride{"errors": {Generator: consts.GeneratorRandom, Value: "oops"}}},
+	})
+
+	cfg := config.MetricTask{Name: "errors", Kind: consts.MetricTypeGauge, Type: consts.ValueTypeInt64, Rate: config.Rate(time.Second), Count: 1, Value: "1", Generator: consts.GeneratorConstant}
+	_, err := New(context.Background(), cfg, WithTimeline(timeline))
+	assert.ErrorContains(t, err, "phase 0")
+}
+
+func TestNewIntervals(t *testing.T) {
+	cfg := *config.NewMetricTask(config.WithCount(3), config.WithRateGenerator(consts.GeneratorSequence, "0.5,2,0.25"))
+
+	intervals, err := newIntervals(context.Background(), cfg, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{500 * time.Millisecond, 2 * time.Second, 250 * time.Millisecond}, slices.Collect(intervals))
+
+	cfg.RateGenerator.Value = "fast"
+	_, err = newIntervals(context.Background(), cfg, nil)
+	assert.Error(t, err)
 }
@@ -0,0 +1,77 @@
+package metrictask
+
+import (
+	"context"
+	"math/rand/v2"
+
+	"github.com/neonmei/szgen/internal/runner/phases"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+type (
+	Option func(*options)
+
+	// RecordInterceptor wraps every recording of a task, the value is handed over
+	// before it's recorded so the interceptor can decorate the context passed to record,
+	// e.g. with an active span so the SDK attaches an exemplar.
+	RecordInterceptor func(ctx context.Context, value float64, record func(context.Context))
+
+	options struct {
+		meterProvider metric.MeterProvider
+		interceptor   RecordInterceptor
+		rand          *rand.Rand
+		timeline      *phases.Timeline
+		derived       []Recorder
+	}
+)
+
+func newOptions(opts ...Option) options {
+	o := options{
+		meterProvider: otel.GetMeterProvider(),
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// WithMeterProvider records the task through a specific provider instead of the global one.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(o *options) {
+		if mp != nil {
+			o.meterProvider = mp
+		}
+	}
+}
+
+// WithRecordInterceptor wraps every recording of the task with an interceptor.
+func WithRecordInterceptor(interceptor RecordInterceptor) Option {
+	return func(o *options) {
+		o.interceptor = interceptor
+	}
+}
+
+// WithRand draws the task random values from a specific source.
+func WithRand(r *rand.Rand) Option {
+	return func(o *options) {
+		o.rand = r
+	}
+}
+
+// WithTimeline switches the task generator as the phases of a timeline go by.
+func WithTimeline(t *phases.Timeline) Option {
+	return func(o *options) {
+		o.timeline = t
+	}
+}
+
+// WithDerived feeds every value the task records to the recorders of derived tasks.
+func WithDerived(recorders ...Recorder) Option {
+	return func(o *options) {
+		o.derived = append(o.derived, recorders...)
+	}
+}
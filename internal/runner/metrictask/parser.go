@@ -1,23 +1,31 @@
 package metrictask
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"iter"
+	"time"
 
 	"github.com/neonmei/szgen/internal/config"
 	"github.com/neonmei/szgen/internal/consts"
 	"github.com/neonmei/szgen/internal/generator"
 	"github.com/neonmei/szgen/internal/runner"
-	"go.opentelemetry.io/otel"
+	"github.com/neonmei/szgen/internal/runner/schedule"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 )
 
-func newInstrument[T int64 | float64](ctx context.Context, cfg config.MetricTask) (runner.Task, error) {
-	attr := parseAttributes(cfg.Attributes)
-	meter := otel.Meter(consts.DefaultMeterName)
+func newInstrument[T int64 | float64](ctx context.Context, cfg config.MetricTask, o options) (runner.Task, error) {
+	attr := runner.ParseAttributes(cfg.Attributes)
+	meter := o.meterProvider.Meter(consts.DefaultMeterName)
 
-	iter, err := generator.New[T](ctx, cfg.Generator, cfg.Value, cfg.Count)
+	var genOpts []generator.Option
+	if o.rand != nil {
+		genOpts = append(genOpts, generator.WithRand(o.rand))
+	}
+
+	iter, err := generator.New[T](ctx, cfg.Generator, cfg.Value, cfg.Count, genOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("create %s iterator: %w", cfg.Kind, err)
 	}
@@ -35,14 +43,136 @@ func newInstrument[T int64 | float64](ctx context.Context, cfg config.MetricTask
 		return nil, err
 	}
 
-	return &metricTask[T]{
+	observedRec := observed(rec.(valueRecorder[T]), runner.NewSeries(cfg.Name, cfg.Kind, attr))
+	if cfg.Level {
+		observedRec = leveled(observedRec)
+	}
+	recorder := withDerived(observedRec, o.derived)
+
+	var release func()
+	if cfg.Latency != nil {
+		recorder, release, err = newRequestRecorder(ctx, meter, cfg, attr, observedRec, o.derived, genOpts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	jitter, err := cfg.JitterDuration()
+	if err != nil {
+		return nil, fmt.Errorf("metric %q: %w", cfg.Name, err)
+	}
+
+	schedOpts := []schedule.Option{
+		schedule.WithEmitImmediately(cfg.EmitImmediately),
+		schedule.WithJitter(jitter),
+		schedule.WithAlign(cfg.Align),
+	}
+	if o.rand != nil {
+		schedOpts = append(schedOpts, schedule.WithRand(o.rand))
+	}
+
+	if cfg.RateGenerator != nil {
+		intervals, err := newIntervals(ctx, cfg, genOpts)
+		if err != nil {
+			return nil, err
+		}
+		schedOpts = append(schedOpts, schedule.WithIntervals(intervals))
+	}
+
+	task := &metricTask[T]{
 		taskName:    cfg.Name,
-		genInterval: cfg.Rate,
+		genInterval: time.Duration(cfg.Rate),
+		schedule:    schedule.New(time.Duration(cfg.Rate), schedOpts...),
+		delay:       cfg.Delay,
+		count:       cfg.Count,
 		genIter:     iter,
-		recorder:    rec.(valueRecorder[T]),
+		recorder:    intercept(recorder, o.interceptor),
+		release:     release,
+	}
+
+	if o.timeline != nil {
+		task.timeline = o.timeline
+		task.phaseIter = func(phase, count int) (generator.ValueGenerator[T], error) {
+			pattern, value := cfg.Generator, cfg.Value
+			if p := o.timeline.Phase(phase); p != nil {
+				pattern, value = p.Apply(cfg)
+			}
+
+			iter, err := generator.New[T](ctx, pattern, value, count, genOpts...)
+			if err != nil {
+				return nil, fmt.Errorf("create %s iterator for phase %d: %w", cfg.Kind, phase, err)
+			}
+
+			return iter, nil
+		}
+
+		// surface invalid phase values now rather than once the phase starts
+		for phase := range o.timeline.Len() {
+			if _, err := task.phaseIter(phase, 0); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return task, nil
+}
+
+// newIntervals converts the seconds yielded by the rate generator into intervals.
+func newIntervals(ctx context.Context, cfg config.MetricTask, genOpts []generator.Option) (iter.Seq[time.Duration], error) {
+	pattern := cmp.Or(cfg.RateGenerator.Generator, consts.DefaultGenerator)
+	seconds, err := generator.New[float64](ctx, pattern, cfg.RateGenerator.Value, cfg.Count, genOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("metric %q: create rate iterator: %w", cfg.Name, err)
+	}
+
+	return func(yield func(time.Duration) bool) {
+		for s := range seconds {
+			if !yield(time.Duration(s * float64(time.Second))) {
+				return
+			}
+		}
 	}, nil
 }
 
+// observed reports every value recorded to a series, for the run report, and counts it
+// among the points of the tick.
+func observed[T int64 | float64](recorder valueRecorder[T], series runner.Series) valueRecorder[T] {
+	return func(ctx context.Context, v T) {
+		recorder(ctx, v)
+		runner.Observe(ctx, series, float64(v))
+		countPoint(ctx)
+	}
+}
+
+// leveled records the change from the previous value, the sum of an updowncounter
+// following the values. Derived metrics are still fed the values.
+func leveled[T int64 | float64](recorder valueRecorder[T]) valueRecorder[T] {
+	var level T
+	return func(ctx context.Context, v T) {
+		recorder(ctx, v-level)
+		level = v
+	}
+}
+
+type pointsKey struct{}
+
+// countPoint adds a point to the tick recorded with ctx, see metricTask.record.
+func countPoint(ctx context.Context) {
+	if points, ok := ctx.Value(pointsKey{}).(*int); ok {
+		*points++
+	}
+}
+
+func intercept[T int64 | float64](recorder valueRecorder[T], interceptor RecordInterceptor) valueRecorder[T] {
+	if interceptor == nil {
+		return recorder
+	}
+
+	return func(ctx context.Context, v T) {
+		interceptor(ctx, float64(v), func(ctx context.Context) { recorder(ctx, v) })
+	}
+}
+
 func newInt64Recorder(m metric.Meter, cfg config.MetricTask, attr []attribute.KeyValue) (valueRecorder[int64], error) {
 	desc := metric.WithDescription(cfg.Description)
 	unit := metric.WithUnit(cfg.Unit)
@@ -0,0 +1,107 @@
+package metrictask
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"iter"
+	"log/slog"
+	"math"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/consts"
+	"github.com/neonmei/szgen/internal/generator"
+	"github.com/neonmei/szgen/internal/runner"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// latencies draws one latency per request, starting over when a finite generator
+// (e.g. sequence) is exhausted. The generator is pulled lazily and released by close.
+type latencies[T int64 | float64] struct {
+	gen  generator.ValueGenerator[T]
+	next func() (T, bool)
+	stop func()
+}
+
+func (l *latencies[T]) sample() (T, error) {
+	if l.next == nil {
+		l.restart()
+	}
+
+	latency, ok := l.next()
+	if !ok {
+		l.restart()
+		if latency, ok = l.next(); !ok {
+			return 0, fmt.Errorf("latency generator yields no values")
+		}
+	}
+
+	return latency, nil
+}
+
+func (l *latencies[T]) restart() {
+	l.close()
+	l.next, l.stop = iter.Pull(iter.Seq[T](l.gen))
+}
+
+func (l *latencies[T]) close() {
+	if l.stop != nil {
+		l.stop()
+		l.next, l.stop = nil, nil
+	}
+}
+
+// newRequestRecorder makes the task values request counts: every tick records one
+// latency observation per request through the histogram recorder, and adds the
+// requests to the companion counter, if any, with the same attributes. Derived
+// metrics are fed the requests of the tick.
+func newRequestRecorder[T int64 | float64](ctx context.Context, m metric.Meter, cfg config.MetricTask, attr []attribute.KeyValue,
+	histogram valueRecorder[T], derived []Recorder, genOpts []generator.Option,
+) (valueRecorder[T], func(), error) {
+	pattern := cmp.Or(cfg.Latency.Generator, consts.DefaultGenerator)
+	gen, err := generator.New[T](ctx, pattern, cfg.Latency.Value, math.MaxInt, genOpts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create latency iterator: %w", err)
+	}
+	samples := &latencies[T]{gen: gen}
+
+	var counter metric.Int64Counter
+	if cfg.Counter != "" {
+		counter, err = m.Int64Counter(cfg.Counter,
+			metric.WithDescription(fmt.Sprintf("Requests recorded by %s", cfg.Name)),
+			metric.WithUnit("{request}"),
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("create int64 counter %q: %w", cfg.Counter, err)
+		}
+	}
+	withAttr := metric.WithAttributes(attr...)
+	series := runner.NewSeries(cfg.Counter, consts.MetricTypeCounter, attr)
+
+	return func(ctx context.Context, v T) {
+		requests := int64(math.Round(float64(v)))
+		if requests <= 0 {
+			return
+		}
+
+		for range requests {
+			latency, err := samples.sample()
+			if err != nil {
+				slog.Error("Failed to sample latency", "metric", cfg.Name, "error", err)
+				return
+			}
+			histogram(ctx, latency)
+		}
+
+		if counter != nil {
+			counter.Add(ctx, requests, withAttr)
+			runner.Observe(ctx, series, float64(requests))
+			countPoint(ctx)
+		}
+
+		for _, d := range derived {
+			d(ctx, float64(requests))
+		}
+	}, samples.close, nil
+}
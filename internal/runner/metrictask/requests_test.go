@@ -0,0 +1,126 @@
+package metrictask
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/consts"
+	"github.com/neonmei/szgen/internal/runner"
+	"github.com/neonmei/szgen/internal/runner/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestRequestDrivenHistogram(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	cfg := *config.NewMetricTask(
+		config.WithName("http.server.request.duration"),
+		config.WithKind(consts.MetricTypeHistogram),
+		config.WithRate(time.Millisecond),
+		config.WithCount(5),
+		config.WithGenerator(consts.GeneratorRandom),
+		config.WithValue("1,40"),
+		config.WithLatency(consts.GeneratorSequence, "0.1,0.2,0.3"),
+		config.WithCounter("http.server.requests"),
+		config.WithMetricAttributes(map[string]any{"http.route": "/checkout"}),
+	)
+
+	var requests float64
+	task, err := New(context.Background(), cfg,
+		WithMeterProvider(mp),
+		WithDerived(func(_ context.Context, v float64) { requests += v }),
+	)
+	require.NoError(t, err)
+	require.NoError(t, task.Execute(context.Background()))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	var histogram metricdata.HistogramDataPoint[float64]
+	var counter metricdata.DataPoint[int64]
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		switch data := m.Data.(type) {
+		case metricdata.Histogram[float64]:
+			histogram = data.DataPoints[0]
+		case metricdata.Sum[int64]:
+			counter = data.DataPoints[0]
+		}
+	}
+
+	assert.Positive(t, counter.Value)
+	assert.Equal(t, uint64(counter.Value), histogram.Count, "one observation per request")
+	assert.Equal(t, float64(counter.Value), requests, "derived metrics follow the requests")
+	assert.True(t, counter.Attributes.Equals(&histogram.Attributes))
+	assert.Equal(t, "/checkout", attrValue(histogram.Attributes, "http.route"))
+
+	// the sequence starts over once exhausted
+	minValue, _ := histogram.Min.Value()
+	maxValue, _ := histogram.Max.Value()
+	assert.Equal(t, 0.1, minValue)
+	assert.Equal(t, 0.3, maxValue)
+}
+
+func TestRequestDrivenHistogram_Points(t *testing.T) {
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewManualReader()))
+
+	errorsCfg := config.NewMetricTask(config.WithName("http.server.errors"), config.WithKind(consts.MetricTypeCounter),
+		config.WithType(consts.ValueTypeInt64), config.WithDerivedFrom("http.server.request.duration", "value * 0.5"))
+	errors, err := NewDerived(*errorsCfg, nil, WithMeterProvider(mp))
+	require.NoError(t, err)
+
+	task, err := New(context.Background(), *config.NewMetricTask(
+		config.WithName("http.server.request.duration"),
+		config.WithKind(consts.MetricTypeHistogram),
+		config.WithRate(time.Second),
+		config.WithCount(2),
+		config.WithGenerator(consts.GeneratorSequence),
+		config.WithValue("2,3"),
+		config.WithLatency(consts.GeneratorRandom, "0.1,0.3"),
+		config.WithCounter("http.server.requests"),
+	), WithMeterProvider(mp), WithDerived(errors))
+	require.NoError(t, err)
+
+	report := runner.NewReport()
+	tasks := report.Track([]runner.Task{task})
+	require.NoError(t, tasks[0].Execute(clock.With(context.Background(), clock.NewInstant(time.Now()))))
+
+	// an observation per request, the counter and the derived errors on every tick
+	assert.Equal(t, int64(2+1+1+3+1+1), report.Summary().Tasks[0].Points)
+}
+
+func TestLatencies_Sample(t *testing.T) {
+	samples := &latencies[int64]{gen: func(yield func(int64) bool) {
+		for _, v := range []int64{1, 2} {
+			if !yield(v) {
+				return
+			}
+		}
+	}}
+	defer samples.close()
+
+	var got []int64
+	for range 5 {
+		v, err := samples.sample()
+		require.NoError(t, err)
+		got = append(got, v)
+	}
+	assert.Equal(t, []int64{1, 2, 1, 2, 1}, got)
+
+	empty := &latencies[int64]{gen: func(func(int64) bool) {}}
+	defer empty.close()
+	_, err := empty.sample()
+	assert.ErrorContains(t, err, "yields no values")
+}
+
+func attrValue(set attribute.Set, key attribute.Key) string {
+	v, _ := set.Value(key)
+	return v.AsString()
+}
@@ -0,0 +1,117 @@
+// Package phases schedules the run timeline, switching the active phase as time goes by.
+package phases
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/runner/clock"
+)
+
+// None is the phase index reported before the timeline starts and once it's over,
+// tasks use their own configuration meanwhile.
+const None = -1
+
+// Timeline tracks which phase is active. Phases are resolved from the elapsed time
+// since Start, so tasks switching on their own ticks agree on the active phase.
+type Timeline struct {
+	phases []config.PhaseConfig
+	run    atomic.Pointer[run]
+}
+
+// run is a pass through the timeline, timed by the clock of the run it belongs to.
+type run struct {
+	start time.Time
+	clock clock.Clock
+}
+
+// New returns nil without phases, a nil Timeline is never started and has no phases.
+func New(phases []config.PhaseConfig) *Timeline {
+	if len(phases) == 0 {
+		return nil
+	}
+
+	return &Timeline{phases: phases}
+}
+
+// Start begins the first phase and logs every transition until the timeline is over.
+func (t *Timeline) Start(ctx context.Context) {
+	if t == nil || len(t.phases) == 0 {
+		return
+	}
+
+	c := clock.From(ctx)
+	r := &run{start: c.Now(), clock: c}
+	t.run.Store(r)
+
+	go func() {
+		for i, phase := range t.phases {
+			slog.Info("Phase started",
+				"phase", phase.Name,
+				"index", i,
+				"duration", phase.Duration,
+				"tasks", len(phase.Tasks),
+			)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-c.After(r.end(t.phases, i).Sub(c.Now())):
+			}
+		}
+
+		slog.Info("Phases completed, tasks back to their configured generators", "phases", len(t.phases))
+	}()
+}
+
+// Current returns the index of the active phase, or None.
+func (t *Timeline) Current() int {
+	if t == nil {
+		return None
+	}
+
+	r := t.run.Load()
+	if r == nil {
+		return None
+	}
+
+	now := r.clock.Now()
+	for i := range t.phases {
+		if now.Before(r.end(t.phases, i)) {
+			return i
+		}
+	}
+
+	return None
+}
+
+// Len returns the amount of phases.
+func (t *Timeline) Len() int {
+	if t == nil {
+		return 0
+	}
+
+	return len(t.phases)
+}
+
+// Phase returns the configuration of a phase, nil for None.
+func (t *Timeline) Phase(index int) *config.PhaseConfig {
+	if t == nil || index < 0 || index >= len(t.phases) {
+		return nil
+	}
+
+	return &t.phases[index]
+}
+
+// end returns when a phase finishes.
+func (r *run) end(phases []config.PhaseConfig, index int) time.Time {
+	end := r.start
+	for _, phase := range phases[:index+1] {
+		end = end.Add(phase.Duration)
+	}
+
+	return end
+}
@@ -0,0 +1,47 @@
+package phases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/runner/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	assert.Nil(t, New(nil))
+
+	var timeline *Timeline
+	timeline.Start(context.Background())
+	assert.Equal(t, None, timeline.Current())
+	assert.Nil(t, timeline.Phase(0))
+	assert.Zero(t, timeline.Len())
+}
+
+func TestTimeline_Current(t *testing.T) {
+	timeline := New([]config.PhaseConfig{
+		{Name: "normal", Duration: 30 * time.Millisecond},
+		{Name: "outage", Duration: 30 * time.Millisecond},
+	})
+	require.Equal(t, 2, timeline.Len())
+	assert.Equal(t, None, timeline.Current(), "not started")
+
+	fake := clock.NewFake(time.Now())
+	ctx, cancel := context.WithCancel(clock.With(context.Background(), fake))
+	defer cancel()
+
+	timeline.Start(ctx)
+	assert.Equal(t, 0, timeline.Current())
+	assert.Equal(t, "normal", timeline.Phase(0).Name)
+
+	fake.Advance(30 * time.Millisecond)
+	assert.Equal(t, 1, timeline.Current())
+	assert.Equal(t, "outage", timeline.Phase(1).Name)
+
+	fake.Advance(30 * time.Millisecond)
+	assert.Equal(t, None, timeline.Current())
+	assert.Nil(t, timeline.Phase(None))
+}
@@ -0,0 +1,266 @@
+package progress
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/neonmei/szgen/internal/consts"
+)
+
+const (
+	barWidth  = 10
+	nameWidth = 24
+
+	// ANSI sequences to move the cursor up and clear what follows it
+	cursorUp  = "\x1b[%dA"
+	clearDown = "\x1b[J"
+)
+
+// Display renders the progress of a tracker. On a terminal the progress of every task is
+// redrawn in place, log lines written through the display scroll above it. Otherwise a
+// summary is logged periodically.
+type Display struct {
+	tracker     *Tracker
+	out         io.Writer
+	interactive bool
+	interval    time.Duration
+
+	mu    sync.Mutex
+	lines int
+
+	done chan struct{}
+	stop context.CancelFunc
+}
+
+// NewDisplay renders the progress of tracker to out, in place if it's a terminal.
+func NewDisplay(tracker *Tracker, out *os.File) *Display {
+	d := &Display{tracker: tracker, out: out, interval: consts.DefaultProgressInterval}
+	if info, err := out.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+		d.interactive = true
+		d.interval = consts.DefaultProgressRefresh
+	}
+
+	return d
+}
+
+// Interactive tells whether the progress is redrawn in place.
+func (d *Display) Interactive() bool {
+	return d.interactive
+}
+
+// Start renders the progress every interval until Stop.
+func (d *Display) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	d.stop = cancel
+	d.done = make(chan struct{})
+
+	go func() {
+		defer close(d.done)
+
+		ticker := time.NewTicker(d.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				d.render()
+			}
+		}
+	}()
+}
+
+// Stop ends the rendering, leaving the final progress on a terminal.
+func (d *Display) Stop() {
+	if d.stop == nil {
+		return
+	}
+
+	d.stop()
+	<-d.done
+
+	if d.interactive {
+		d.render()
+
+		d.mu.Lock()
+		d.lines = 0
+		d.mu.Unlock()
+	}
+}
+
+// Write writes p above the progress, it's meant to be the output of the logger while
+// the progress is displayed on a terminal.
+func (d *Display) Write(p []byte) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !d.interactive || d.lines == 0 {
+		return d.out.Write(p)
+	}
+
+	d.clear()
+	n, err := d.out.Write(p)
+	d.draw(d.tracker.Tasks())
+
+	return n, err
+}
+
+func (d *Display) render() {
+	tasks := d.tracker.Tasks()
+	if !d.interactive {
+		logProgress(tasks)
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.clear()
+	d.draw(tasks)
+}
+
+// clear erases the progress drawn last, the cursor ends up where it began.
+func (d *Display) clear() {
+	if d.lines > 0 {
+		fmt.Fprintf(d.out, cursorUp+clearDown, d.lines)
+		d.lines = 0
+	}
+}
+
+func (d *Display) draw(tasks []TaskProgress) {
+	var sb strings.Builder
+	for _, line := range Lines(tasks) {
+		sb.WriteString(line)
+		sb.WriteByte('\n')
+		d.lines++
+	}
+
+	_, _ = io.WriteString(d.out, sb.String())
+}
+
+// Lines formats the progress of every task on its own line, followed by a summary.
+// Past consts.MaxProgressTasks the finished tasks are left out first.
+func Lines(tasks []TaskProgress) []string {
+	shown := tasks
+	if len(shown) > consts.MaxProgressTasks {
+		shown = make([]TaskProgress, 0, consts.MaxProgressTasks)
+		for _, task := range tasks {
+			if !task.Done && len(shown) < consts.MaxProgressTasks {
+				shown = append(shown, task)
+			}
+		}
+	}
+
+	lines := make([]string, 0, len(shown)+1)
+	for _, task := range shown {
+		lines = append(lines, formatTask(task))
+	}
+
+	summary := summarize(tasks)
+	line := fmt.Sprintf("%d/%d tasks done, %d ticks", summary.done, len(tasks), summary.ticks)
+	if hidden := len(tasks) - len(shown); hidden > 0 {
+		line += fmt.Sprintf(", %d more tasks not shown", hidden)
+	}
+
+	return append(lines, line)
+}
+
+func formatTask(task TaskProgress) string {
+	name := task.Name
+	if len(name) > nameWidth {
+		name = name[:nameWidth-3] + "..."
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%-*s ", nameWidth, name)
+
+	if percent := task.Percent(); percent >= 0 {
+		filled := int(percent / 100 * barWidth)
+		fmt.Fprintf(&sb, "[%s%s] %d/%d %5.1f%%", strings.Repeat("#", filled), strings.Repeat("-", barWidth-filled),
+			task.Ticks, task.Total, percent)
+	} else {
+		fmt.Fprintf(&sb, "%d ticks", task.Ticks)
+	}
+
+	if task.HasValue {
+		sb.WriteString("  " + strconv.FormatFloat(task.Value, 'g', 4, 64))
+	}
+
+	switch {
+	case task.Done:
+		fmt.Fprintf(&sb, "  done %s", task.Elapsed.Round(time.Second))
+	case task.Running:
+		fmt.Fprintf(&sb, "  %s", task.Elapsed.Round(time.Second))
+		if eta := task.ETA(); eta > 0 {
+			fmt.Fprintf(&sb, "  eta %s", eta.Round(time.Second))
+		}
+	default:
+		sb.WriteString("  pending")
+	}
+
+	return sb.String()
+}
+
+type summary struct {
+	done    int
+	running int
+	ticks   int64
+}
+
+func summarize(tasks []TaskProgress) summary {
+	var s summary
+	for _, task := range tasks {
+		s.ticks += task.Ticks
+		switch {
+		case task.Done:
+			s.done++
+		case task.Running:
+			s.running++
+		}
+	}
+
+	return s
+}
+
+// logProgress logs a summary of the run, followed by the progress of the tasks running.
+func logProgress(tasks []TaskProgress) {
+	s := summarize(tasks)
+	slog.Info("Progress",
+		"tasks", len(tasks),
+		"running", s.running,
+		"done", s.done,
+		"ticks", s.ticks,
+	)
+
+	logged := 0
+	for _, task := range tasks {
+		if !task.Running || logged == consts.MaxProgressTasks {
+			continue
+		}
+		logged++
+
+		args := []any{
+			"task", task.Name,
+			"ticks", task.Ticks,
+			"elapsed", task.Elapsed.Round(time.Second),
+		}
+		if task.Total > 0 {
+			args = append(args, "total", task.Total, "percent", strconv.FormatFloat(task.Percent(), 'f', 1, 64))
+		}
+		if task.HasValue {
+			args = append(args, "value", task.Value)
+		}
+		if eta := task.ETA(); eta > 0 {
+			args = append(args, "eta", eta.Round(time.Second))
+		}
+		slog.Info("Task progress", args...)
+	}
+}
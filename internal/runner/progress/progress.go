@@ -0,0 +1,153 @@
+// Package progress follows how far along every task of a run is. Tasks announce the ticks
+// they are about to go through with Begin and count them with Tick, both only touching
+// atomics so the emission timing is not affected. A Display renders the progress.
+package progress
+
+import (
+	"context"
+	"math"
+	"sync/atomic"
+	"time"
+
+	"github.com/neonmei/szgen/internal/runner"
+)
+
+// Tracker follows the progress of the tasks it tracks, a nil Tracker tracks nothing.
+type Tracker struct {
+	tasks []*trackedTask
+}
+
+// TaskProgress is a snapshot of the current run of a task. Total is 0 when unknown, Value
+// is the last value recorded if HasValue.
+type TaskProgress struct {
+	Name     string
+	Ticks    int64
+	Total    int64
+	Value    float64
+	HasValue bool
+	Elapsed  time.Duration
+	Running  bool
+	Done     bool
+}
+
+type trackedTask struct {
+	runner.Task
+
+	total    atomic.Int64
+	ticks    atomic.Int64
+	value    atomic.Uint64
+	hasValue atomic.Bool
+	start    atomic.Int64
+	end      atomic.Int64
+}
+
+type progressKey struct{}
+
+func New() *Tracker {
+	return &Tracker{}
+}
+
+// Track wraps tasks so their progress is followed.
+func (t *Tracker) Track(tasks []runner.Task) []runner.Task {
+	if t == nil {
+		return tasks
+	}
+
+	tracked := make([]runner.Task, 0, len(tasks))
+	for _, task := range tasks {
+		tt := &trackedTask{Task: task}
+		t.tasks = append(t.tasks, tt)
+		tracked = append(tracked, tt)
+	}
+
+	return tracked
+}
+
+// Tasks returns the progress of every tracked task, in the order they were tracked.
+func (t *Tracker) Tasks() []TaskProgress {
+	now := time.Now()
+	tasks := make([]TaskProgress, 0, len(t.tasks))
+	for _, tt := range t.tasks {
+		tasks = append(tasks, tt.progress(now))
+	}
+
+	return tasks
+}
+
+func (tt *trackedTask) Execute(ctx context.Context) error {
+	err := tt.Task.Execute(context.WithValue(ctx, progressKey{}, tt))
+
+	end := time.Now().UnixNano()
+	tt.start.CompareAndSwap(0, end)
+	tt.end.Store(end)
+
+	return err
+}
+
+func (tt *trackedTask) progress(now time.Time) TaskProgress {
+	p := TaskProgress{
+		Name:     tt.Name(),
+		Ticks:    tt.ticks.Load(),
+		Total:    tt.total.Load(),
+		Value:    math.Float64frombits(tt.value.Load()),
+		HasValue: tt.hasValue.Load(),
+	}
+
+	start, end := tt.start.Load(), tt.end.Load()
+	switch {
+	case start == 0:
+	case end == 0:
+		p.Running = true
+		p.Elapsed = now.Sub(time.Unix(0, start))
+	default:
+		p.Done = true
+		p.Elapsed = time.Duration(end - start)
+	}
+
+	return p
+}
+
+// Percent is how far along the run is, from 0 to 100, or -1 when the total is unknown.
+func (p TaskProgress) Percent() float64 {
+	if p.Total <= 0 {
+		return -1
+	}
+
+	return min(float64(p.Ticks)/float64(p.Total)*100, 100)
+}
+
+// ETA estimates the time left from the pace so far, 0 when it can't tell yet.
+func (p TaskProgress) ETA() time.Duration {
+	if !p.Running || p.Total <= 0 || p.Ticks == 0 || p.Ticks >= p.Total {
+		return 0
+	}
+
+	perTick := float64(p.Elapsed) / float64(p.Ticks)
+	return time.Duration(perTick * float64(p.Total-p.Ticks))
+}
+
+// Begin starts a run of total ticks of the task running with ctx, e.g. the count of a
+// metric task, once its delay is over. Repeated runs start over. Totals beyond an int32
+// are deemed unknown, as tasks running until interrupted get a count of math.MaxInt.
+func Begin(ctx context.Context, total int) {
+	if tt, ok := ctx.Value(progressKey{}).(*trackedTask); ok {
+		if total > math.MaxInt32 {
+			total = 0
+		}
+
+		tt.total.Store(int64(total))
+		tt.ticks.Store(0)
+		tt.hasValue.Store(false)
+		tt.end.Store(0)
+		tt.start.Store(time.Now().UnixNano())
+	}
+}
+
+// Tick counts a tick of the task running with ctx, value being what it just recorded.
+func Tick(ctx context.Context, value float64) {
+	if tt, ok := ctx.Value(progressKey{}).(*trackedTask); ok {
+		tt.ticks.Add(1)
+		tt.value.Store(math.Float64bits(value))
+		tt.hasValue.Store(true)
+	}
+}
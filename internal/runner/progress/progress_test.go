@@ -0,0 +1,130 @@
+package progress
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neonmei/szgen/internal/runner"
+	"github.com/neonmei/szgen/internal/runner/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func tickingTask(name string, total int, values ...float64) runner.Task {
+	return &mocks.MockTask{
+		NameVal: name,
+		ExecuteFunc: func(ctx context.Context) error {
+			Begin(ctx, total)
+			for _, value := range values {
+				Tick(ctx, value)
+			}
+			return nil
+		},
+	}
+}
+
+func TestTracker_Track(t *testing.T) {
+	tracker := New()
+	tasks := tracker.Track([]runner.Task{
+		tickingTask("requests", 10, 1, 2, 3),
+		tickingTask("forever", math.MaxInt, 7),
+		tickingTask("pending", 5),
+	})
+
+	require.NoError(t, tasks[0].Execute(context.Background()))
+	require.NoError(t, tasks[1].Execute(context.Background()))
+
+	progress := tracker.Tasks()
+	require.Len(t, progress, 3)
+
+	requests := progress[0]
+	assert.Equal(t, "requests", requests.Name)
+	assert.Equal(t, int64(3), requests.Ticks)
+	assert.Equal(t, int64(10), requests.Total)
+	assert.Equal(t, 3.0, requests.Value)
+	assert.True(t, requests.HasValue)
+	assert.True(t, requests.Done)
+	assert.InDelta(t, 30, requests.Percent(), 0.001)
+
+	forever := progress[1]
+	assert.Zero(t, forever.Total, "tasks running until interrupted have no total")
+	assert.Equal(t, -1.0, forever.Percent())
+
+	pending := progress[2]
+	assert.False(t, pending.Running)
+	assert.False(t, pending.Done)
+	assert.False(t, pending.HasValue)
+
+	require.NoError(t, tasks[0].Execute(context.Background()))
+	assert.Equal(t, int64(3), tracker.Tasks()[0].Ticks, "repeated runs start over")
+}
+
+func TestTaskProgress_ETA(t *testing.T) {
+	running := TaskProgress{Ticks: 25, Total: 100, Elapsed: time.Minute, Running: true}
+	assert.Equal(t, 3*time.Minute, running.ETA())
+
+	assert.Zero(t, TaskProgress{Total: 100, Elapsed: time.Minute, Running: true}.ETA(), "no pace before the first tick")
+	assert.Zero(t, TaskProgress{Ticks: 25, Elapsed: time.Minute, Running: true}.ETA(), "unknown total")
+	assert.Zero(t, TaskProgress{Ticks: 100, Total: 100, Elapsed: time.Minute, Done: true}.ETA())
+}
+
+func TestUntracked(t *testing.T) {
+	assert.NotPanics(t, func() {
+		Begin(context.Background(), 10)
+		Tick(context.Background(), 1)
+	})
+
+	var tracker *Tracker
+	tasks := []runner.Task{tickingTask("task", 1)}
+	assert.Equal(t, tasks, tracker.Track(tasks))
+}
+
+func TestLines(t *testing.T) {
+	lines := Lines([]TaskProgress{
+		{Name: "http.server.requests", Ticks: 25, Total: 100, Value: 97, HasValue: true, Elapsed: time.Minute, Running: true},
+		{Name: "a.very.long.metric.name.that.does.not.fit", Ticks: 3, Elapsed: time.Second, Running: true},
+		{Name: "done", Ticks: 10, Total: 10, Elapsed: 10 * time.Second, Done: true},
+		{Name: "pending"},
+	})
+
+	assert.Equal(t, []string{
+		"http.server.requests     [##--------] 25/100  25.0%  97  1m0s  eta 3m0s",
+		"a.very.long.metric.na... 3 ticks  1s",
+		"done                     [##########] 10/10 100.0%  done 10s",
+		"pending                  0 ticks  pending",
+		"1/4 tasks done, 38 ticks",
+	}, lines)
+}
+
+func TestLines_ManyTasks(t *testing.T) {
+	tasks := make([]TaskProgress, 30)
+	for i := range tasks {
+		tasks[i] = TaskProgress{Name: "task", Done: i < 15, Running: i >= 15}
+	}
+
+	lines := Lines(tasks)
+	require.Len(t, lines, 16)
+	assert.Equal(t, "15/30 tasks done, 0 ticks, 15 more tasks not shown", lines[15], "finished tasks are left out first")
+}
+
+func TestDisplay_Write(t *testing.T) {
+	tracker := New()
+	tasks := tracker.Track([]runner.Task{tickingTask("requests", 10, 1)})
+	require.NoError(t, tasks[0].Execute(context.Background()))
+
+	var out bytes.Buffer
+	d := &Display{tracker: tracker, out: &out, interactive: true}
+
+	d.render()
+	assert.Equal(t, 2, d.lines)
+
+	out.Reset()
+	_, err := d.Write([]byte("level=INFO msg=log\n"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(out.String(), "\x1b[2A\x1b[Jlevel=INFO msg=log\nrequests"), "logs scroll above the progress")
+	assert.Equal(t, 2, d.lines)
+}
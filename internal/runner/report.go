@@ -0,0 +1,305 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/neonmei/szgen/internal/consts"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Report sums up what every task of a run recorded, per task and per series, so it can be
+// compared with what the backend stored. Tasks report their points through Throttle and
+// the values of their series through Observe.
+type Report struct {
+	mu    sync.Mutex
+	tasks []*reportedTask
+	start time.Time
+	end   time.Time
+}
+
+// Series identifies the values a task records under a metric and attribute set.
+type Series struct {
+	Metric     string
+	Kind       string
+	Attributes attribute.Set
+
+	key seriesKey
+}
+
+type seriesKey struct {
+	metric     string
+	kind       string
+	attributes attribute.Distinct
+}
+
+// RunReport is the outcome of a run, with a TaskReport per task in the order they were
+// tracked. Tasks sharing a name, e.g. replicas, are reported separately.
+type RunReport struct {
+	Start time.Time    `json:"start"`
+	End   time.Time    `json:"end"`
+	Tasks []TaskReport `json:"tasks"`
+}
+
+// TaskReport is what a task recorded over all its runs, a task runs more than once when
+// repeated or retried. Points counts data points, spans or log records.
+type TaskReport struct {
+	Name   string         `json:"name"`
+	Start  time.Time      `json:"start"`
+	End    time.Time      `json:"end"`
+	Runs   int            `json:"runs"`
+	Points int64          `json:"points"`
+	Errors []string       `json:"errors,omitempty"`
+	Series []SeriesReport `json:"series,omitempty"`
+}
+
+// SeriesReport holds the statistics of the values recorded to a series. ExpectedTotal is
+// set for counters, it's the value a cumulative backend should end up with.
+type SeriesReport struct {
+	Metric        string            `json:"metric"`
+	Kind          string            `json:"kind"`
+	Attributes    map[string]string `json:"attributes,omitempty"`
+	Points        int64             `json:"points"`
+	Sum           float64           `json:"sum"`
+	Min           float64           `json:"min"`
+	Max           float64           `json:"max"`
+	Last          float64           `json:"last"`
+	ExpectedTotal *float64          `json:"expected_total,omitempty"`
+}
+
+type reportedTask struct {
+	Task
+	report *Report
+	points atomic.Int64
+
+	mu     sync.Mutex
+	start  time.Time
+	end    time.Time
+	runs   int
+	errors []string
+	series []*seriesStats
+	index  map[seriesKey]*seriesStats
+}
+
+type seriesStats struct {
+	series Series
+	points int64
+	sum    float64
+	min    float64
+	max    float64
+	last   float64
+}
+
+type reportKey struct{}
+
+func NewReport() *Report {
+	return &Report{}
+}
+
+func NewSeries(metric, kind string, attrs []attribute.KeyValue) Series {
+	set := attribute.NewSet(attrs...)
+	return Series{Metric: metric, Kind: kind, Attributes: set, key: seriesKey{metric, kind, set.Equivalent()}}
+}
+
+// Track wraps tasks so what they record is reported.
+func (r *Report) Track(tasks []Task) []Task {
+	tracked := make([]Task, 0, len(tasks))
+	for _, task := range tasks {
+		rt := &reportedTask{Task: task, report: r, index: make(map[seriesKey]*seriesStats)}
+		r.tasks = append(r.tasks, rt)
+		tracked = append(tracked, rt)
+	}
+
+	return tracked
+}
+
+// Summary returns the report of the run so far.
+func (r *Report) Summary() RunReport {
+	r.mu.Lock()
+	summary := RunReport{Start: r.start, End: r.end, Tasks: make([]TaskReport, 0, len(r.tasks))}
+	r.mu.Unlock()
+
+	for _, rt := range r.tasks {
+		summary.Tasks = append(summary.Tasks, rt.summary())
+	}
+
+	return summary
+}
+
+// Log writes the report as text, a line per task followed by a line per series.
+func (r *Report) Log() {
+	summary := r.Summary()
+	for _, task := range summary.Tasks {
+		slog.Info("Task report",
+			"task", task.Name,
+			"runs", task.Runs,
+			"points", task.Points,
+			"start", task.Start.Format(time.RFC3339Nano),
+			"end", task.End.Format(time.RFC3339Nano),
+			"errors", len(task.Errors),
+		)
+
+		for _, series := range task.Series {
+			args := []any{
+				"task", task.Name,
+				"metric", series.Metric,
+				"kind", series.Kind,
+				"points", series.Points,
+				"sum", series.Sum,
+				"min", series.Min,
+				"max", series.Max,
+				"last", series.Last,
+			}
+			if len(series.Attributes) > 0 {
+				args = append(args, "attributes", series.Attributes)
+			}
+			if series.ExpectedTotal != nil {
+				args = append(args, "expected_total", *series.ExpectedTotal)
+			}
+			slog.Info("Series report", args...)
+		}
+
+		for _, err := range task.Errors {
+			slog.Warn("Task error", "task", task.Name, "error", err)
+		}
+	}
+}
+
+// WriteJSON writes the report to a JSON file.
+func (r *Report) WriteJSON(path string) error {
+	data, err := json.MarshalIndent(r.Summary(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal report: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), consts.DefaultFilePerm); err != nil {
+		return fmt.Errorf("failed to write report %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func (rt *reportedTask) Execute(ctx context.Context) error {
+	start := time.Now()
+	rt.report.started(start)
+
+	err := rt.Task.Execute(context.WithValue(ctx, reportKey{}, rt))
+
+	end := time.Now()
+	rt.report.finished(end)
+
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	if rt.start.IsZero() {
+		rt.start = start
+	}
+	rt.end = end
+	rt.runs++
+	if err != nil {
+		rt.errors = append(rt.errors, err.Error())
+	}
+
+	return err
+}
+
+func (rt *reportedTask) observe(series Series, value float64) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	stats, ok := rt.index[series.key]
+	if !ok {
+		stats = &seriesStats{series: series, min: math.Inf(1), max: math.Inf(-1)}
+		rt.index[series.key] = stats
+		rt.series = append(rt.series, stats)
+	}
+
+	stats.points++
+	stats.sum += value
+	stats.min = min(stats.min, value)
+	stats.max = max(stats.max, value)
+	stats.last = value
+}
+
+func (rt *reportedTask) summary() TaskReport {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	task := TaskReport{
+		Name:   rt.Name(),
+		Start:  rt.start,
+		End:    rt.end,
+		Runs:   rt.runs,
+		Points: rt.points.Load(),
+		Errors: slices.Clone(rt.errors),
+	}
+
+	for _, stats := range rt.series {
+		series := SeriesReport{
+			Metric: stats.series.Metric,
+			Kind:   stats.series.Kind,
+			Points: stats.points,
+			Sum:    stats.sum,
+			Min:    stats.min,
+			Max:    stats.max,
+			Last:   stats.last,
+		}
+
+		if stats.series.Attributes.Len() > 0 {
+			series.Attributes = make(map[string]string, stats.series.Attributes.Len())
+			for _, kv := range stats.series.Attributes.ToSlice() {
+				series.Attributes[string(kv.Key)] = kv.Value.Emit()
+			}
+		}
+
+		if stats.series.Kind == consts.MetricTypeCounter || stats.series.Kind == consts.MetricTypeUpDownCounter {
+			total := stats.sum
+			series.ExpectedTotal = &total
+		}
+
+		task.Series = append(task.Series, series)
+	}
+
+	return task
+}
+
+func (r *Report) started(at time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.start.IsZero() || at.Before(r.start) {
+		r.start = at
+	}
+}
+
+func (r *Report) finished(at time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if at.After(r.end) {
+		r.end = at
+	}
+}
+
+// Observe reports a value recorded to a series by the task running with ctx, if it's
+// tracked by a report.
+func Observe(ctx context.Context, series Series, value float64) {
+	if rt, ok := ctx.Value(reportKey{}).(*reportedTask); ok {
+		rt.observe(series, value)
+	}
+}
+
+// reportPoints adds n points to the task reported in the context, if any.
+func reportPoints(ctx context.Context, n int) {
+	if rt, ok := ctx.Value(reportKey{}).(*reportedTask); ok {
+		rt.points.Add(int64(n))
+	}
+}
@@ -0,0 +1,105 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/neonmei/szgen/internal/consts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type seriesTask struct {
+	name   string
+	values []float64
+	err    error
+}
+
+func (t *seriesTask) Name() string { return t.name }
+
+func (t *seriesTask) Execute(ctx context.Context) error {
+	requests := NewSeries("http.requests", consts.MetricTypeCounter, []attribute.KeyValue{attribute.String("route", "/api")})
+	latency := NewSeries("http.latency", consts.MetricTypeGauge, nil)
+
+	for _, value := range t.values {
+		if err := Throttle(ctx, 1); err != nil {
+			return err
+		}
+
+		Observe(ctx, requests, value)
+		Observe(ctx, latency, value*10)
+	}
+
+	return t.err
+}
+
+func TestReport_Track(t *testing.T) {
+	report := NewReport()
+	tasks := report.Track([]Task{
+		&seriesTask{name: "requests", values: []float64{3, 1, 2}},
+		&seriesTask{name: "broken", err: errors.New("failed")},
+	})
+
+	for _, task := range tasks {
+		_ = task.Execute(context.Background())
+	}
+	require.NoError(t, tasks[0].Execute(context.Background()), "repeated runs add up")
+
+	summary := report.Summary()
+	require.Len(t, summary.Tasks, 2)
+	assert.False(t, summary.Start.IsZero())
+	assert.False(t, summary.End.Before(summary.Start))
+
+	requests := summary.Tasks[0]
+	assert.Equal(t, "requests", requests.Name)
+	assert.Equal(t, 2, requests.Runs)
+	assert.Equal(t, int64(6), requests.Points)
+	assert.Empty(t, requests.Errors)
+
+	total := 12.0
+	assert.Equal(t, []SeriesReport{
+		{
+			Metric: "http.requests", Kind: consts.MetricTypeCounter, Attributes: map[string]string{"route": "/api"},
+			Points: 6, Sum: 12, Min: 1, Max: 3, Last: 2, ExpectedTotal: &total,
+		},
+		{Metric: "http.latency", Kind: consts.MetricTypeGauge, Points: 6, Sum: 120, Min: 10, Max: 30, Last: 20},
+	}, requests.Series)
+
+	broken := summary.Tasks[1]
+	assert.Equal(t, 1, broken.Runs)
+	assert.Equal(t, []string{"failed"}, broken.Errors)
+	assert.Empty(t, broken.Series)
+
+	report.Log()
+}
+
+func TestReport_WriteJSON(t *testing.T) {
+	report := NewReport()
+	tasks := report.Track([]Task{&seriesTask{name: "requests", values: []float64{1}}})
+	require.NoError(t, tasks[0].Execute(context.Background()))
+
+	path := filepath.Join(t.TempDir(), "report.json")
+	require.NoError(t, report.WriteJSON(path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var summary RunReport
+	require.NoError(t, json.Unmarshal(data, &summary))
+	require.Len(t, summary.Tasks, 1)
+	assert.Equal(t, int64(1), summary.Tasks[0].Points)
+	assert.Equal(t, 1.0, *summary.Tasks[0].Series[0].ExpectedTotal)
+
+	assert.Error(t, report.WriteJSON(filepath.Join(t.TempDir(), "missing", "report.json")))
+}
+
+func TestObserve_Untracked(t *testing.T) {
+	assert.NotPanics(t, func() {
+		Observe(context.Background(), NewSeries("http.requests", consts.MetricTypeCounter, nil), 1)
+	})
+}
@@ -0,0 +1,59 @@
+package scenario
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type (
+	Option  func(*options)
+	options struct {
+		meterProvider  metric.MeterProvider
+		tracerProvider trace.TracerProvider
+		loggerProvider log.LoggerProvider
+	}
+)
+
+func newOptions(opts ...Option) options {
+	o := options{
+		meterProvider:  otel.GetMeterProvider(),
+		tracerProvider: otel.GetTracerProvider(),
+		loggerProvider: global.GetLoggerProvider(),
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// WithMeterProvider records latencies through a specific provider instead of the global one.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(o *options) {
+		if mp != nil {
+			o.meterProvider = mp
+		}
+	}
+}
+
+// WithTracerProvider emits request spans through a specific provider instead of the global one.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(o *options) {
+		if tp != nil {
+			o.tracerProvider = tp
+		}
+	}
+}
+
+// WithLoggerProvider emits request logs through a specific provider instead of the global one.
+func WithLoggerProvider(lp log.LoggerProvider) Option {
+	return func(o *options) {
+		if lp != nil {
+			o.loggerProvider = lp
+		}
+	}
+}
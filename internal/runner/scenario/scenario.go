@@ -0,0 +1,156 @@
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/consts"
+	"github.com/neonmei/szgen/internal/runner"
+	"github.com/neonmei/szgen/internal/runner/clock"
+	"github.com/neonmei/szgen/internal/runner/metrictask"
+	"github.com/neonmei/szgen/internal/runner/spanmodel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// bodyData is available to scenario log body templates.
+type bodyData struct {
+	Name    string
+	Index   int
+	Latency float64
+	Error   bool
+}
+
+// scenarioTask is the latency histogram task, named after the scenario.
+type scenarioTask struct {
+	runner.Task
+	taskName string
+	model    *spanmodel.Model
+}
+
+func (st *scenarioTask) Name() string {
+	return st.taskName
+}
+
+func (st *scenarioTask) Execute(ctx context.Context) error {
+	st.model.Reseed(runner.Iteration(ctx))
+	defer st.model.Close()
+	return st.Task.Execute(ctx)
+}
+
+// request emits the span and log record correlated to every latency observation.
+type request struct {
+	tracer    trace.Tracer
+	logger    log.Logger
+	name      string
+	spanKind  trace.SpanKind
+	model     *spanmodel.Model
+	body      *template.Template
+	spanAttrs []attribute.KeyValue
+	logAttrs  []log.KeyValue
+	index     int
+}
+
+// intercept wraps the histogram recording of a request with its span, the observation
+// is recorded with the span context so the SDK samples it as an exemplar.
+func (r *request) intercept(ctx context.Context, latency float64, record func(context.Context)) {
+	end := clock.From(ctx).Now()
+	start := end.Add(-time.Duration(max(latency, 0) * float64(time.Millisecond)))
+
+	ctx, span := r.tracer.Start(r.model.Context(ctx), r.name,
+		trace.WithTimestamp(start),
+		trace.WithSpanKind(r.spanKind),
+		trace.WithAttributes(r.spanAttrs...),
+		trace.WithLinks(r.model.Links()...),
+	)
+	defer span.End(trace.WithTimestamp(end))
+	r.model.Remember(span.SpanContext())
+
+	failed, err := r.model.Apply(span, 0, r.spanKind, start, end)
+	if err != nil {
+		slog.Warn("Failed to model request span", "scenario", r.name, "error", err)
+	}
+
+	record(ctx)
+	r.emitLog(ctx, latency, failed, end)
+	r.index++
+}
+
+func (r *request) emitLog(ctx context.Context, latency float64, failed bool, timestamp time.Time) {
+	var body strings.Builder
+	data := bodyData{Name: r.name, Index: r.index, Latency: latency, Error: failed}
+	if err := r.body.Execute(&body, data); err != nil {
+		body.Reset()
+		body.WriteString(err.Error())
+	}
+
+	var record log.Record
+	record.SetTimestamp(timestamp)
+	record.SetObservedTimestamp(timestamp)
+	if failed {
+		record.SetSeverity(log.SeverityError)
+		record.SetSeverityText(strings.ToUpper(consts.SeverityError))
+	} else {
+		record.SetSeverity(log.SeverityInfo)
+		record.SetSeverityText(strings.ToUpper(consts.SeverityInfo))
+	}
+	record.SetBody(log.StringValue(body.String()))
+	record.AddAttributes(r.logAttrs...)
+
+	// the SDK takes trace and span IDs from the span in ctx
+	r.logger.Emit(ctx, record)
+}
+
+// New creates a runnable scenario from model (file, cli, etc) configuration. The scenario
+// runs as a latency histogram metric task whose recordings are wrapped with a span and a log record.
+func New(ctx context.Context, sTask config.ScenarioTask, opts ...Option) (runner.Task, error) {
+	if err := sTask.Validate(); err != nil {
+		return nil, err
+	}
+
+	o := newOptions(opts...)
+
+	body, err := template.New(sTask.Name).Parse(sTask.Body)
+	if err != nil {
+		return nil, fmt.Errorf("parse scenario body: %w", err)
+	}
+
+	attrs := runner.ParseAttributes(sTask.Attributes)
+	logAttrs := make([]log.KeyValue, 0, len(attrs))
+	for _, attr := range attrs {
+		logAttrs = append(logAttrs, log.KeyValueFromAttribute(attr))
+	}
+
+	model, err := spanmodel.New(ctx, sTask.SpanModel)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &request{
+		tracer:    o.tracerProvider.Tracer(consts.DefaultTracerName),
+		logger:    o.loggerProvider.Logger(consts.DefaultLoggerName),
+		name:      sTask.Name,
+		spanKind:  runner.ParseSpanKind(sTask.SpanKind),
+		model:     model,
+		body:      body,
+		spanAttrs: attrs,
+		logAttrs:  logAttrs,
+	}
+
+	task, err := metrictask.New(ctx, sTask.MetricTask(),
+		metrictask.WithMeterProvider(o.meterProvider),
+		metrictask.WithRecordInterceptor(req.intercept),
+		metrictask.WithRand(model.DurationRand()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create latency task: %w", err)
+	}
+
+	return &scenarioTask{Task: task, taskName: sTask.Name, model: model}, nil
+}
@@ -0,0 +1,142 @@
+package scenario
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type recordingExporter struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+func (e *recordingExporter) Export(_ context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, r := range records {
+		e.records = append(e.records, r.Clone())
+	}
+	return nil
+}
+
+func (e *recordingExporter) Shutdown(context.Context) error   { return nil }
+func (e *recordingExporter) ForceFlush(context.Context) error { return nil }
+
+type providers struct {
+	reader *sdkmetric.ManualReader
+	spans  *tracetest.SpanRecorder
+	logs   *recordingExporter
+	opts   []Option
+}
+
+func newProviders() providers {
+	p := providers{
+		reader: sdkmetric.NewManualReader(),
+		spans:  tracetest.NewSpanRecorder(),
+		logs:   &recordingExporter{},
+	}
+
+	p.opts = []Option{
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(p.reader))),
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(p.spans))),
+		WithLoggerProvider(sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(p.logs)))),
+	}
+
+	return p
+}
+
+func TestScenario_Execute(t *testing.T) {
+	t.Run("correlate span, exemplar and log", func(t *testing.T) {
+		p := newProviders()
+		cfg := config.NewScenarioTask(
+			config.WithScenarioName("GET /checkout"),
+			config.WithScenarioMetric("http.server.request.duration"),
+			config.WithScenarioRate(time.Millisecond),
+			config.WithScenarioCount(3),
+			config.WithScenarioValue("50"),
+			config.WithScenarioBody("{{ .Name }} request {{ .Index }} took {{ .Latency }}ms"),
+		)
+
+		task, err := New(context.Background(), *cfg, p.opts...)
+		require.NoError(t, err)
+		assert.Equal(t, "GET /checkout", task.Name())
+		require.NoError(t, task.Execute(context.Background()))
+
+		spans := p.spans.Ended()
+		require.Len(t, spans, 3)
+		require.Len(t, p.logs.records, 3)
+
+		for i, span := range spans {
+			record := p.logs.records[i]
+			assert.Equal(t, "GET /checkout", span.Name())
+			assert.Equal(t, 50*time.Millisecond, span.EndTime().Sub(span.StartTime()))
+			assert.Equal(t, span.SpanContext().TraceID(), record.TraceID())
+			assert.Equal(t, span.SpanContext().SpanID(), record.SpanID())
+			assert.Equal(t, log.SeverityInfo, record.Severity())
+		}
+		assert.Equal(t, "GET /checkout request 2 took 50ms", p.logs.records[2].Body().AsString())
+
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, p.reader.Collect(context.Background(), &rm))
+		require.Len(t, rm.ScopeMetrics, 1)
+		require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
+
+		metric := rm.ScopeMetrics[0].Metrics[0]
+		assert.Equal(t, "http.server.request.duration", metric.Name)
+
+		hist, ok := metric.Data.(metricdata.Histogram[float64])
+		require.True(t, ok)
+		require.Len(t, hist.DataPoints, 1)
+		assert.Equal(t, uint64(3), hist.DataPoints[0].Count)
+		require.NotEmpty(t, hist.DataPoints[0].Exemplars)
+
+		traceIDs := make(map[[16]byte]bool, len(spans))
+		for _, span := range spans {
+			traceIDs[span.SpanContext().TraceID()] = true
+		}
+		for _, exemplar := range hist.DataPoints[0].Exemplars {
+			var traceID [16]byte
+			copy(traceID[:], exemplar.TraceID)
+			assert.True(t, traceIDs[traceID])
+		}
+	})
+
+	t.Run("errors mark span and log", func(t *testing.T) {
+		p := newProviders()
+		cfg := config.NewScenarioTask(
+			config.WithScenarioRate(time.Millisecond),
+			config.WithScenarioCount(2),
+			config.WithScenarioErrorRatio(1),
+		)
+
+		task, err := New(context.Background(), *cfg, p.opts...)
+		require.NoError(t, err)
+		require.NoError(t, task.Execute(context.Background()))
+
+		for _, span := range p.spans.Ended() {
+			assert.Equal(t, codes.Error, span.Status().Code)
+		}
+		for _, record := range p.logs.records {
+			assert.Equal(t, log.SeverityError, record.Severity())
+		}
+	})
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	cfg := config.NewScenarioTask(config.WithScenarioMetric("1invalid"))
+	_, err := New(context.Background(), *cfg)
+	assert.Error(t, err)
+}
@@ -0,0 +1,170 @@
+// Package schedule times the data points of a task: every point is due one interval
+// after the time the previous one was due, rather than after it was recorded, so delays
+// never accumulate, no matter how long the run or how late a point was recorded.
+package schedule
+
+import (
+	"context"
+	"iter"
+	"math/rand/v2"
+	"time"
+
+	"github.com/neonmei/szgen/internal/runner/clock"
+)
+
+type (
+	Option func(*Schedule)
+
+	// Schedule is restarted by every execution of its task, it's not safe for concurrent use.
+	Schedule struct {
+		interval  time.Duration
+		jitter    time.Duration
+		align     time.Duration
+		immediate bool
+		rand      *rand.Rand
+		intervals iter.Seq[time.Duration]
+
+		due     time.Time
+		started bool
+		next    func() (time.Duration, bool)
+		stop    func()
+	}
+)
+
+// WithEmitImmediately makes the first point due at the origin rather than one interval later.
+func WithEmitImmediately(immediate bool) Option {
+	return func(s *Schedule) {
+		s.immediate = immediate
+	}
+}
+
+// WithJitter delays every point by a random duration up to jitter, points are never
+// early so they stay within their aligned interval as long as jitter is smaller.
+func WithJitter(jitter time.Duration) Option {
+	return func(s *Schedule) {
+		s.jitter = jitter
+	}
+}
+
+// WithAlign moves the origin of the grid to the next multiple of align since the Unix
+// epoch, e.g. the next minute, so tasks started at different times line up.
+func WithAlign(align time.Duration) Option {
+	return func(s *Schedule) {
+		s.align = align
+	}
+}
+
+// WithIntervals varies the time between points, e.g. exponential inter-arrival times
+// for Poisson timing. Intervals start over once exhausted, negative ones are due right
+// away.
+func WithIntervals(intervals iter.Seq[time.Duration]) Option {
+	return func(s *Schedule) {
+		s.intervals = intervals
+	}
+}
+
+// WithRand draws jitter from a specific source, making it reproducible when seeded.
+func WithRand(r *rand.Rand) Option {
+	return func(s *Schedule) {
+		s.rand = r
+	}
+}
+
+func New(interval time.Duration, opts ...Option) *Schedule {
+	s := &Schedule{interval: interval}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Start lays the points out from now, or from the next align boundary.
+func (s *Schedule) Start(now time.Time) {
+	s.due = now
+	if s.align > 0 {
+		// time.Truncate counts from the zero time, boundaries count from the Unix epoch
+		offset := time.Duration(now.UnixNano() % int64(s.align))
+		if offset < 0 {
+			offset += s.align
+		}
+		if offset > 0 {
+			s.due = now.Add(s.align - offset)
+		}
+	}
+
+	s.Stop()
+	if s.intervals != nil {
+		s.next, s.stop = iter.Pull(s.intervals)
+	}
+
+	s.started = false
+	if !s.immediate {
+		s.due = s.due.Add(s.nextInterval())
+	}
+}
+
+// Stop releases the intervals, Start pulls them again from the beginning.
+func (s *Schedule) Stop() {
+	if s.stop != nil {
+		s.stop()
+		s.next, s.stop = nil, nil
+	}
+}
+
+// Next returns when the next point is due and moves on to the following one.
+func (s *Schedule) Next() time.Time {
+	if s.started {
+		s.due = s.due.Add(s.nextInterval())
+	}
+	s.started = true
+
+	if s.jitter > 0 {
+		return s.due.Add(s.jitterN(s.jitter))
+	}
+
+	return s.due
+}
+
+// Wait blocks until the next point is due on the clock of the run. A point already due
+// returns right away, so a late point is recorded as soon as possible and the following
+// ones keep their time.
+func (s *Schedule) Wait(ctx context.Context) error {
+	c := clock.From(ctx)
+	wait := s.Next().Sub(c.Now())
+	if wait <= 0 {
+		return ctx.Err()
+	}
+
+	select {
+	case <-c.After(wait):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Schedule) nextInterval() time.Duration {
+	if s.next == nil {
+		return s.interval
+	}
+
+	interval, ok := s.next()
+	if !ok {
+		s.Stop()
+		s.next, s.stop = iter.Pull(s.intervals)
+		if interval, ok = s.next(); !ok {
+			return s.interval
+		}
+	}
+
+	return max(interval, 0)
+}
+
+func (s *Schedule) jitterN(n time.Duration) time.Duration {
+	if s.rand != nil {
+		return time.Duration(s.rand.Int64N(int64(n) + 1))
+	}
+
+	return time.Duration(rand.Int64N(int64(n) + 1))
+}
@@ -0,0 +1,156 @@
+package schedule
+
+import (
+	"context"
+	"math/rand/v2"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/neonmei/szgen/internal/runner/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedule_Next(t *testing.T) {
+	now := time.Date(2025, 3, 14, 15, 9, 26, 535_000_000, time.UTC)
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		expected []time.Time
+	}{
+		{
+			name:     "one interval after start",
+			expected: []time.Time{now.Add(10 * time.Second), now.Add(20 * time.Second), now.Add(30 * time.Second)},
+		},
+		{
+			name:     "emit immediately",
+			opts:     []Option{WithEmitImmediately(true)},
+			expected: []time.Time{now, now.Add(10 * time.Second), now.Add(20 * time.Second)},
+		},
+		{
+			name: "aligned on the minute",
+			opts: []Option{WithAlign(time.Minute), WithEmitImmediately(true)},
+			expected: []time.Time{
+				time.Date(2025, 3, 14, 15, 10, 0, 0, time.UTC),
+				time.Date(2025, 3, 14, 15, 10, 10, 0, time.UTC),
+				time.Date(2025, 3, 14, 15, 10, 20, 0, time.UTC),
+			},
+		},
+		{
+			name: "aligned without emitting immediately",
+			opts: []Option{WithAlign(time.Minute)},
+			expected: []time.Time{
+				time.Date(2025, 3, 14, 15, 10, 10, 0, time.UTC),
+				time.Date(2025, 3, 14, 15, 10, 20, 0, time.UTC),
+			},
+		},
+		{
+			name: "aligned on the unix epoch",
+			opts: []Option{WithAlign(7 * time.Minute), WithEmitImmediately(true)},
+			expected: []time.Time{
+				time.Date(2025, 3, 14, 15, 12, 0, 0, time.UTC),
+				time.Date(2025, 3, 14, 15, 12, 10, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(10*time.Second, tt.opts...)
+			s.Start(now)
+
+			for _, expected := range tt.expected {
+				assert.Equal(t, expected, s.Next())
+			}
+		})
+	}
+}
+
+func TestSchedule_Intervals(t *testing.T) {
+	now := time.Now()
+	intervals := slices.Values([]time.Duration{time.Second, 3 * time.Second, -time.Second})
+
+	s := New(time.Hour, WithIntervals(intervals))
+	s.Start(now)
+	defer s.Stop()
+
+	expected := []time.Duration{1, 4, 4, 5, 8}
+	for _, offset := range expected {
+		assert.Equal(t, now.Add(offset*time.Second), s.Next(), "intervals start over once exhausted")
+	}
+
+	t.Run("restart", func(t *testing.T) {
+		s.Start(now)
+		assert.Equal(t, now.Add(time.Second), s.Next())
+	})
+
+	t.Run("empty intervals", func(t *testing.T) {
+		s := New(time.Minute, WithIntervals(slices.Values([]time.Duration{})), WithEmitImmediately(true))
+		s.Start(now)
+		defer s.Stop()
+
+		assert.Equal(t, now, s.Next())
+		assert.Equal(t, now.Add(time.Minute), s.Next(), "falls back to the interval")
+	})
+}
+
+func TestSchedule_AlignedBoundary(t *testing.T) {
+	boundary := time.Date(2025, 3, 14, 15, 10, 0, 0, time.UTC)
+
+	s := New(time.Second, WithAlign(time.Minute), WithEmitImmediately(true))
+	s.Start(boundary)
+	assert.Equal(t, boundary, s.Next(), "starting on a boundary doesn't wait for the next one")
+}
+
+func TestSchedule_Jitter(t *testing.T) {
+	now := time.Now()
+	s := New(time.Second, WithJitter(100*time.Millisecond), WithRand(rand.New(rand.NewPCG(1, 2))))
+	s.Start(now)
+
+	for n := 1; n <= 1000; n++ {
+		due := s.Next()
+		grid := now.Add(time.Duration(n) * time.Second)
+		require.False(t, due.Before(grid), "points are never early")
+		require.LessOrEqual(t, due.Sub(grid), 100*time.Millisecond, "jitter doesn't accumulate")
+	}
+}
+
+func TestSchedule_Wait(t *testing.T) {
+	start := time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC)
+
+	t.Run("no drift", func(t *testing.T) {
+		fake := clock.NewInstant(start)
+		ctx := clock.With(context.Background(), fake)
+
+		s := New(5 * time.Millisecond)
+		s.Start(start)
+
+		for range 20 {
+			require.NoError(t, s.Wait(ctx))
+			fake.Advance(time.Millisecond) // recording takes time
+		}
+
+		assert.Equal(t, start.Add(101*time.Millisecond), fake.Now(), "recording time doesn't delay the following points")
+	})
+
+	t.Run("late points are due right away", func(t *testing.T) {
+		fake := clock.NewInstant(start)
+
+		s := New(time.Millisecond)
+		s.Start(start.Add(-time.Second))
+
+		require.NoError(t, s.Wait(clock.With(context.Background(), fake)))
+		assert.Equal(t, start, fake.Now())
+	})
+
+	t.Run("cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		s := New(time.Hour)
+		s.Start(time.Now())
+		assert.ErrorIs(t, s.Wait(ctx), context.Canceled)
+	})
+}
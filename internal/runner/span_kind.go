@@ -0,0 +1,22 @@
+package runner
+
+import (
+	"github.com/neonmei/szgen/internal/consts"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ParseSpanKind maps a configured span kind to its OpenTelemetry value, unknown kinds are internal.
+func ParseSpanKind(kind string) trace.SpanKind {
+	switch kind {
+	case consts.SpanKindServer:
+		return trace.SpanKindServer
+	case consts.SpanKindClient:
+		return trace.SpanKindClient
+	case consts.SpanKindProducer:
+		return trace.SpanKindProducer
+	case consts.SpanKindConsumer:
+		return trace.SpanKindConsumer
+	default:
+		return trace.SpanKindInternal
+	}
+}
@@ -0,0 +1,255 @@
+// Package spanmodel decides what generated spans carry besides their timing: status,
+// events and links. Every decision is drawn from sources derived from the configured
+// seed and the iteration of the run, so a seeded task emits the same traces on every
+// run and every retry.
+package spanmodel
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/consts"
+	"github.com/neonmei/szgen/internal/generator"
+	"github.com/neonmei/szgen/internal/idgen"
+	"github.com/neonmei/szgen/internal/runner"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// random streams derived from the seed, kept apart so e.g. adding an event
+// does not change the generated durations or IDs.
+const (
+	streamDurations uint64 = iota + 1
+	streamDecisions
+	streamIDs
+	streamStatus
+)
+
+type event struct {
+	name        string
+	probability float64
+	levels      []int
+	onError     bool
+	attrs       []attribute.KeyValue
+}
+
+type Model struct {
+	seed       uint64
+	seeded     bool
+	sources    map[uint64]*rand.PCG
+	durations  *rand.Rand
+	decisions  *rand.Rand
+	ids        *rand.Rand
+	errorRatio float64
+
+	statusCodes     generator.ValueGenerator[int64]
+	statusAttribute string
+	nextStatus      func() (int64, bool)
+	stopStatus      func()
+
+	events          []event
+	links           config.SpanLinks
+	linkProbability float64
+	history         []trace.SpanContext
+}
+
+// New creates the model of a task. The context allows cancelling the status generator.
+func New(ctx context.Context, cfg config.SpanModel) (*Model, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	m := &Model{
+		seed:       cfg.Seed,
+		seeded:     cfg.Seed != 0,
+		sources:    make(map[uint64]*rand.PCG),
+		errorRatio: cfg.ErrorRatio,
+		links:      cfg.Links,
+	}
+	m.durations = m.newRand(streamDurations)
+	m.decisions = m.newRand(streamDecisions)
+	m.ids = m.newRand(streamIDs)
+
+	m.linkProbability = cfg.Links.Probability
+	if m.linkProbability == 0 {
+		m.linkProbability = 1
+	}
+
+	if cfg.Status != nil {
+		m.statusAttribute = cfg.Status.Attribute
+		if m.statusAttribute == "" {
+			m.statusAttribute = consts.DefaultStatusAttribute
+		}
+
+		statusCodes, err := generator.New[int64](ctx, cfg.Status.Generator, cfg.Status.Value, math.MaxInt32,
+			generator.WithRand(m.newRand(streamStatus)))
+		if err != nil {
+			return nil, fmt.Errorf("create status iterator: %w", err)
+		}
+		m.statusCodes = statusCodes
+	}
+
+	for _, e := range cfg.Events {
+		probability := e.Probability
+		if probability == 0 {
+			probability = 1
+		}
+
+		m.events = append(m.events, event{
+			name:        e.Name,
+			probability: probability,
+			levels:      e.Levels,
+			onError:     e.OnError,
+			attrs:       runner.ParseAttributes(e.Attributes),
+		})
+	}
+
+	m.Reseed(1)
+	return m, nil
+}
+
+func (m *Model) newRand(stream uint64) *rand.Rand {
+	src := &rand.PCG{}
+	m.sources[stream] = src
+	return rand.New(src)
+}
+
+// Reseed starts every stream over for an iteration of the run, tasks call it whenever
+// they execute so retries replay the same traces. Without a configured seed every
+// execution draws from a new random seed.
+func (m *Model) Reseed(iteration int) {
+	if !m.seeded {
+		m.seed = rand.Uint64()
+	}
+
+	for stream, src := range m.sources {
+		runner.SeedStream(src, m.seed, stream, iteration)
+	}
+	m.history = nil
+	m.Close()
+}
+
+// DurationRand is the source span durations should be drawn from, Reseed starts it over too.
+func (m *Model) DurationRand() *rand.Rand {
+	return m.durations
+}
+
+// Context makes spans started with the returned context draw reproducible IDs.
+func (m *Model) Context(ctx context.Context) context.Context {
+	return idgen.ContextWithRand(ctx, m.ids)
+}
+
+// Links returns the links of a new root span to earlier traces.
+func (m *Model) Links() []trace.Link {
+	if m.links.Count == 0 || len(m.history) == 0 || m.decisions.Float64() >= m.linkProbability {
+		return nil
+	}
+
+	links := make([]trace.Link, 0, m.links.Count)
+	for range min(m.links.Count, len(m.history)) {
+		sc := m.history[m.decisions.IntN(len(m.history))]
+		links = append(links, trace.Link{SpanContext: sc})
+	}
+
+	return links
+}
+
+// Remember keeps a root span so later traces can link to it.
+func (m *Model) Remember(sc trace.SpanContext) {
+	if m.links.Count == 0 {
+		return
+	}
+
+	if len(m.history) == consts.MaxLinkedTraces {
+		m.history = slices.Delete(m.history, 0, 1)
+	}
+	m.history = append(m.history, sc)
+}
+
+// Apply decides the status and events of a span lasting from start to end at a tree
+// level, reporting whether the span failed.
+func (m *Model) Apply(span trace.Span, level int, kind trace.SpanKind, start, end time.Time) (bool, error) {
+	failed, err := m.applyStatus(span, kind)
+	if err != nil {
+		return false, err
+	}
+
+	for _, e := range m.events {
+		if e.onError && !failed {
+			continue
+		}
+
+		if len(e.levels) > 0 && !slices.Contains(e.levels, level) {
+			continue
+		}
+
+		if m.decisions.Float64() >= e.probability {
+			continue
+		}
+
+		offset := time.Duration(m.decisions.Float64() * float64(end.Sub(start)))
+		span.AddEvent(e.name, trace.WithTimestamp(start.Add(offset)), trace.WithAttributes(e.attrs...))
+	}
+
+	return failed, nil
+}
+
+func (m *Model) applyStatus(span trace.Span, kind trace.SpanKind) (bool, error) {
+	if m.statusCodes == nil {
+		failed := m.errorRatio > 0 && m.decisions.Float64() < m.errorRatio
+		if failed {
+			span.SetStatus(codes.Error, "synthetic error")
+		}
+		return failed, nil
+	}
+
+	if m.nextStatus == nil {
+		m.restartStatus()
+	}
+
+	code, ok := m.nextStatus()
+	if !ok {
+		// finite generators (e.g. sequence) start over
+		m.restartStatus()
+		if code, ok = m.nextStatus(); !ok {
+			return false, fmt.Errorf("status generator yields no values")
+		}
+	}
+
+	span.SetAttributes(attribute.Int64(m.statusAttribute, code))
+
+	threshold := int64(consts.StatusCodeServerError)
+	if kind == trace.SpanKindClient {
+		threshold = consts.StatusCodeClientError
+	}
+
+	failed := code >= threshold
+	if failed {
+		span.SetStatus(codes.Error, fmt.Sprintf("status code %d", code))
+	}
+
+	return failed, nil
+}
+
+func (m *Model) restartStatus() {
+	if m.stopStatus != nil {
+		m.stopStatus()
+	}
+
+	m.nextStatus, m.stopStatus = iter.Pull(iter.Seq[int64](m.statusCodes))
+}
+
+// Close releases the status generator, it starts over if the model is applied again.
+func (m *Model) Close() {
+	if m.stopStatus != nil {
+		m.stopStatus()
+		m.nextStatus, m.stopStatus = nil, nil
+	}
+}
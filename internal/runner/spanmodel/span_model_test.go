@@ -0,0 +1,156 @@
+package spanmodel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/consts"
+	"github.com/neonmei/szgen/internal/idgen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newTracer() (*tracetest.SpanRecorder, trace.Tracer) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder), sdktrace.WithIDGenerator(idgen.New()))
+	return recorder, tp.Tracer("test")
+}
+
+// emit starts and ends a span per kind applying the model, as tasks do.
+func emit(t *testing.T, m *Model, tracer trace.Tracer, level int, kinds ...trace.SpanKind) {
+	t.Helper()
+
+	ctx := m.Context(context.Background())
+	end := time.Now()
+	start := end.Add(-100 * time.Millisecond)
+
+	for _, kind := range kinds {
+		_, span := tracer.Start(ctx, "op", trace.WithSpanKind(kind), trace.WithTimestamp(start), trace.WithLinks(m.Links()...))
+		m.Remember(span.SpanContext())
+		_, err := m.Apply(span, level, kind, start, end)
+		require.NoError(t, err)
+		span.End(trace.WithTimestamp(end))
+	}
+}
+
+func TestModel_Status(t *testing.T) {
+	t.Run("error ratio", func(t *testing.T) {
+		recorder, tracer := newTracer()
+		m, err := New(context.Background(), config.SpanModel{ErrorRatio: 1})
+		require.NoError(t, err)
+
+		emit(t, m, tracer, 0, trace.SpanKindServer)
+		assert.Equal(t, codes.Error, recorder.Ended()[0].Status().Code)
+	})
+
+	t.Run("status codes cycle", func(t *testing.T) {
+		recorder, tracer := newTracer()
+		m, err := New(context.Background(), config.SpanModel{
+			Status: &config.SpanStatus{Generator: consts.GeneratorSequence, Value: "200,404,503"},
+		})
+		require.NoError(t, err)
+		defer m.Close()
+
+		emit(t, m, tracer, 0,
+			trace.SpanKindServer, trace.SpanKindServer, trace.SpanKindServer,
+			trace.SpanKindClient, trace.SpanKindClient,
+		)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 5)
+
+		wantCodes := []int64{200, 404, 503, 200, 404}
+		wantStatus := []codes.Code{codes.Unset, codes.Unset, codes.Error, codes.Unset, codes.Error}
+		for i, span := range spans {
+			assert.Contains(t, span.Attributes(), attribute.Int64(consts.DefaultStatusAttribute, wantCodes[i]))
+			assert.Equal(t, wantStatus[i], span.Status().Code, "span %d", i)
+		}
+	})
+}
+
+func TestModel_Events(t *testing.T) {
+	recorder, tracer := newTracer()
+	m, err := New(context.Background(), config.SpanModel{
+		ErrorRatio: 1,
+		Events: []config.SpanEvent{
+			{Name: "cache.miss", Levels: []int{1}},
+			{Name: "exception", OnError: true, Attributes: map[string]any{"exception.type": "TimeoutError"}},
+			{Name: "retry", Probability: 0.5},
+		},
+	})
+	require.NoError(t, err)
+
+	emit(t, m, tracer, 0, trace.SpanKindServer)
+	root := recorder.Ended()[0]
+
+	names := make([]string, 0, len(root.Events()))
+	for _, event := range root.Events() {
+		names = append(names, event.Name)
+		assert.False(t, event.Time.Before(root.StartTime()))
+		assert.False(t, event.Time.After(root.EndTime()))
+	}
+	assert.NotContains(t, names, "cache.miss")
+	assert.Contains(t, names, "exception")
+	assert.Equal(t, attribute.String("exception.type", "TimeoutError"), root.Events()[0].Attributes[0])
+}
+
+func TestModel_Links(t *testing.T) {
+	recorder, tracer := newTracer()
+	m, err := New(context.Background(), config.SpanModel{Links: config.SpanLinks{Count: 2}})
+	require.NoError(t, err)
+
+	emit(t, m, tracer, 0, trace.SpanKindServer, trace.SpanKindServer, trace.SpanKindServer)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	assert.Empty(t, spans[0].Links())
+	require.Len(t, spans[1].Links(), 1)
+	assert.Equal(t, spans[0].SpanContext().TraceID(), spans[1].Links()[0].SpanContext.TraceID())
+	assert.Len(t, spans[2].Links(), 2)
+}
+
+func TestModel_Seed(t *testing.T) {
+	cfg := config.SpanModel{
+		ErrorRatio: 0.5,
+		Events:     []config.SpanEvent{{Name: "retry", Probability: 0.5}},
+		Links:      config.SpanLinks{Count: 1, Probability: 0.5},
+		Seed:       42,
+	}
+
+	run := func() []sdktrace.ReadOnlySpan {
+		recorder, tracer := newTracer()
+		m, err := New(context.Background(), cfg)
+		require.NoError(t, err)
+
+		kinds := make([]trace.SpanKind, 50)
+		for i := range kinds {
+			kinds[i] = trace.SpanKindServer
+		}
+		emit(t, m, tracer, 0, kinds...)
+		return recorder.Ended()
+	}
+
+	first, second := run(), run()
+	require.Len(t, second, len(first))
+	for i := range first {
+		assert.Equal(t, first[i].SpanContext().TraceID(), second[i].SpanContext().TraceID())
+		assert.Equal(t, first[i].Status(), second[i].Status())
+		assert.Equal(t, len(first[i].Events()), len(second[i].Events()))
+		assert.Equal(t, len(first[i].Links()), len(second[i].Links()))
+	}
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	_, err := New(context.Background(), config.SpanModel{
+		ErrorRatio: 0.1,
+		Status:     &config.SpanStatus{Generator: consts.GeneratorConstant, Value: "200"},
+	})
+	assert.ErrorContains(t, err, "mutually exclusive")
+}
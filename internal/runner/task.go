@@ -2,9 +2,33 @@ package runner
 
 import (
 	"context"
+	"math/rand/v2"
 )
 
 type Task interface {
 	Execute(context.Context) error
 	Name() string
 }
+
+type iterationKey struct{}
+
+// WithIteration tells tasks executed with the returned context which iteration of a
+// repeated run they belong to.
+func WithIteration(ctx context.Context, iteration int) context.Context {
+	return context.WithValue(ctx, iterationKey{}, iteration)
+}
+
+// Iteration is the iteration of the run a task executes in, 1 when the run doesn't repeat.
+func Iteration(ctx context.Context) int {
+	if iteration, ok := ctx.Value(iterationKey{}).(int); ok {
+		return iteration
+	}
+
+	return 1
+}
+
+// SeedStream seeds a random stream of a task for an iteration of the run, so every
+// execution of a seeded task in that iteration, retries included, draws the same values.
+func SeedStream(src *rand.PCG, seed, stream uint64, iteration int) {
+	src.Seed(seed, stream<<32|uint64(iteration))
+}
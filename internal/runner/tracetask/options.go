@@ -0,0 +1,34 @@
+package tracetask
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type (
+	Option  func(*options)
+	options struct {
+		tracerProvider trace.TracerProvider
+	}
+)
+
+func newOptions(opts ...Option) options {
+	o := options{
+		tracerProvider: otel.GetTracerProvider(),
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// WithTracerProvider emits the task spans through a specific provider instead of the global one.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(o *options) {
+		if tp != nil {
+			o.tracerProvider = tp
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package tracetask
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"log/slog"
+	"time"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/consts"
+	"github.com/neonmei/szgen/internal/generator"
+	"github.com/neonmei/szgen/internal/runner"
+	"github.com/neonmei/szgen/internal/runner/clock"
+	"github.com/neonmei/szgen/internal/runner/progress"
+	"github.com/neonmei/szgen/internal/runner/schedule"
+	"github.com/neonmei/szgen/internal/runner/spanmodel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type traceTask struct {
+	tracer      trace.Tracer
+	durations   generator.ValueGenerator[float64]
+	genInterval time.Duration
+	taskName    string
+	count       int
+	depth       int
+	fanOut      int
+	kinds       []trace.SpanKind
+	model       *spanmodel.Model
+	attrs       []attribute.KeyValue
+}
+
+func (tt *traceTask) Name() string {
+	return tt.taskName
+}
+
+func (tt *traceTask) Execute(ctx context.Context) error {
+	slog.Info("Trace task running", "trace", tt.taskName, "interval", tt.genInterval)
+
+	sched := schedule.New(tt.genInterval)
+	sched.Start(clock.From(ctx).Now())
+
+	tt.model.Reseed(runner.Iteration(ctx))
+	next, stop := iter.Pull(iter.Seq[float64](tt.durations))
+	defer stop()
+	defer tt.model.Close()
+
+	ctx = tt.model.Context(ctx)
+	progress.Begin(ctx, tt.count)
+
+	for {
+		if err := sched.Wait(ctx); err != nil {
+			return err
+		}
+
+		rootDuration, ok := next()
+		if !ok {
+			slog.Info("Completed execution", "trace", tt.taskName)
+			return nil
+		}
+
+		end := clock.From(ctx).Now()
+		spans, err := tt.emitSpan(ctx, next, 0, 0, end.Add(-toDuration(rootDuration)), end)
+		if err != nil {
+			return err
+		}
+		progress.Tick(ctx, float64(spans))
+		slog.Debug("Emitted trace", "trace", tt.taskName, "spans", spans)
+	}
+}
+
+// emitSpan records a span between start and end, laying out its children one after
+// another in equal slots of the parent duration. It returns the amount of spans emitted.
+func (tt *traceTask) emitSpan(ctx context.Context, next func() (float64, bool), level, index int, start, end time.Time) (int, error) {
+	name := tt.taskName
+	if level > 0 {
+		name = fmt.Sprintf("%s.%d.%d", tt.taskName, level, index)
+	}
+
+	if err := runner.Throttle(ctx, 1); err != nil {
+		return 0, err
+	}
+
+	kind := tt.spanKind(level)
+	opts := []trace.SpanStartOption{
+		trace.WithTimestamp(start),
+		trace.WithSpanKind(kind),
+		trace.WithAttributes(tt.attrs...),
+	}
+	if level == 0 {
+		opts = append(opts, trace.WithLinks(tt.model.Links()...))
+	}
+
+	ctx, span := tt.tracer.Start(ctx, name, opts...)
+	defer span.End(trace.WithTimestamp(end))
+
+	if level == 0 {
+		tt.model.Remember(span.SpanContext())
+	}
+
+	if _, err := tt.model.Apply(span, level, kind, start, end); err != nil {
+		return 0, err
+	}
+
+	spans := 1
+	if level+1 >= tt.depth {
+		return spans, nil
+	}
+
+	slot := end.Sub(start) / time.Duration(tt.fanOut)
+	for i := range tt.fanOut {
+		duration, ok := next()
+		if !ok {
+			break
+		}
+
+		childStart := start.Add(time.Duration(i) * slot)
+		childEnd := childStart.Add(min(toDuration(duration), slot))
+		children, err := tt.emitSpan(ctx, next, level+1, i, childStart, childEnd)
+		if err != nil {
+			return spans, err
+		}
+		spans += children
+	}
+
+	return spans, nil
+}
+
+func (tt *traceTask) spanKind(level int) trace.SpanKind {
+	if len(tt.kinds) == 0 {
+		if level == 0 {
+			return trace.SpanKindServer
+		}
+		return trace.SpanKindInternal
+	}
+
+	return tt.kinds[min(level, len(tt.kinds)-1)]
+}
+
+// toDuration converts a generated value in milliseconds into a duration.
+func toDuration(millis float64) time.Duration {
+	return time.Duration(max(millis, 0) * float64(time.Millisecond))
+}
+
+// New creates a runnable task from model (file, cli, etc) configuration.
+// The context here allows cancelling generation at the producer (i.e: value generator) level.
+func New(ctx context.Context, tTask config.TraceTask, opts ...Option) (runner.Task, error) {
+	if err := tTask.Validate(); err != nil {
+		return nil, err
+	}
+
+	o := newOptions(opts...)
+
+	model, err := spanmodel.New(ctx, tTask.SpanModel)
+	if err != nil {
+		return nil, err
+	}
+
+	durations, err := generator.New[float64](ctx, tTask.Generator, tTask.Value, tTask.Count*tTask.SpansPerTrace(),
+		generator.WithRand(model.DurationRand()))
+	if err != nil {
+		return nil, fmt.Errorf("create span duration iterator: %w", err)
+	}
+
+	kinds := make([]trace.SpanKind, 0, len(tTask.SpanKinds))
+	for _, kind := range tTask.SpanKinds {
+		kinds = append(kinds, runner.ParseSpanKind(kind))
+	}
+
+	return &traceTask{
+		tracer:      o.tracerProvider.Tracer(consts.DefaultTracerName),
+		durations:   durations,
+		genInterval: tTask.Rate,
+		taskName:    tTask.Name,
+		count:       tTask.Count,
+		depth:       tTask.Depth,
+		fanOut:      tTask.FanOut,
+		kinds:       kinds,
+		model:       model,
+		attrs:       runner.ParseAttributes(tTask.Attributes),
+	}, nil
+}
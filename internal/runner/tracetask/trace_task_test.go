@@ -0,0 +1,232 @@
+package tracetask
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/neonmei/szgen/internal/config"
+	"github.com/neonmei/szgen/internal/consts"
+	"github.com/neonmei/szgen/internal/idgen"
+	"github.com/neonmei/szgen/internal/runner"
+	"github.com/neonmei/szgen/internal/runner/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newRecorder() (*tracetest.SpanRecorder, trace.TracerProvider) {
+	recorder := tracetest.NewSpanRecorder()
+	return recorder, sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder), sdktrace.WithIDGenerator(idgen.New()))
+}
+
+func TestTraceTask_Execute(t *testing.T) {
+	t.Run("emit span trees", func(t *testing.T) {
+		recorder, tp := newRecorder()
+		cfg := config.NewTraceTask(
+			config.WithSpanName("checkout"),
+			config.WithTraceRate(time.Millisecond),
+			config.WithTraceCount(2),
+			config.WithDepth(3),
+			config.WithFanOut(2),
+			config.WithSpanKinds([]string{consts.SpanKindServer, consts.SpanKindClient}),
+			config.WithTraceValue("100"),
+			config.WithTraceAttributes(map[string]any{"env": "test"}),
+		)
+
+		task, err := New(context.Background(), *cfg, WithTracerProvider(tp))
+		require.NoError(t, err)
+		assert.Equal(t, "checkout", task.Name())
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		require.NoError(t, task.Execute(ctx))
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 2*cfg.SpansPerTrace())
+
+		traces := make(map[trace.TraceID]int)
+		for _, span := range spans {
+			traces[span.SpanContext().TraceID()]++
+			assert.Contains(t, span.Attributes(), attribute.String("env", "test"))
+
+			if !span.Parent().IsValid() {
+				assert.Equal(t, "checkout", span.Name())
+				assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+				assert.Equal(t, 100*time.Millisecond, span.EndTime().Sub(span.StartTime()))
+				continue
+			}
+
+			assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+		}
+
+		assert.Len(t, traces, 2)
+		for _, count := range traces {
+			assert.Equal(t, cfg.SpansPerTrace(), count)
+		}
+	})
+
+	t.Run("children fit in parent", func(t *testing.T) {
+		recorder, tp := newRecorder()
+		cfg := config.NewTraceTask(
+			config.WithTraceRate(time.Millisecond),
+			config.WithTraceCount(1),
+			config.WithDepth(2),
+			config.WithFanOut(4),
+			config.WithTraceValue("100"),
+		)
+
+		task, err := New(context.Background(), *cfg, WithTracerProvider(tp))
+		require.NoError(t, err)
+		require.NoError(t, task.Execute(context.Background()))
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 5)
+
+		var root sdktrace.ReadOnlySpan
+		for _, span := range spans {
+			if !span.Parent().IsValid() {
+				root = span
+			}
+		}
+		require.NotNil(t, root)
+
+		for _, span := range spans {
+			if span == root {
+				continue
+			}
+			assert.Equal(t, root.SpanContext().SpanID(), span.Parent().SpanID())
+			assert.Equal(t, trace.SpanKindInternal, span.SpanKind())
+			assert.False(t, span.StartTime().Before(root.StartTime()))
+			assert.False(t, span.EndTime().After(root.EndTime()))
+			assert.Equal(t, 25*time.Millisecond, span.EndTime().Sub(span.StartTime()))
+		}
+	})
+
+	t.Run("error ratio", func(t *testing.T) {
+		recorder, tp := newRecorder()
+		cfg := config.NewTraceTask(
+			config.WithTraceRate(time.Millisecond),
+			config.WithTraceCount(1),
+			config.WithErrorRatio(1),
+		)
+
+		task, err := New(context.Background(), *cfg, WithTracerProvider(tp))
+		require.NoError(t, err)
+		require.NoError(t, task.Execute(context.Background()))
+
+		for _, span := range recorder.Ended() {
+			assert.Equal(t, codes.Error, span.Status().Code)
+		}
+	})
+
+	t.Run("stop on context cancellation", func(t *testing.T) {
+		_, tp := newRecorder()
+		cfg := config.NewTraceTask(config.WithTraceCount(0))
+
+		task, err := New(context.Background(), *cfg, WithTracerProvider(tp))
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, task.Execute(ctx), context.DeadlineExceeded)
+	})
+}
+
+func TestTraceTask_Clock(t *testing.T) {
+	recorder, tp := newRecorder()
+	cfg := config.NewTraceTask(config.WithTraceRate(time.Minute), config.WithTraceCount(3), config.WithTraceValue("100"))
+
+	task, err := New(context.Background(), *cfg, WithTracerProvider(tp))
+	require.NoError(t, err)
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, task.Execute(clock.With(context.Background(), clock.NewInstant(start))))
+
+	spans := recorder.Ended()
+	require.NotEmpty(t, spans)
+	for _, span := range spans {
+		assert.False(t, span.EndTime().Before(start), "span timestamps follow the clock of the run")
+		assert.False(t, span.EndTime().After(start.Add(3*time.Minute)), "span timestamps follow the clock of the run")
+	}
+}
+
+func TestTraceTask_Seed(t *testing.T) {
+	cfg := config.NewTraceTask(
+		config.WithTraceRate(time.Millisecond),
+		config.WithTraceCount(5),
+		config.WithDepth(3),
+		config.WithTraceGenerator(consts.GeneratorRandom),
+		config.WithTraceValue("10,100"),
+		config.WithSpanModel(config.SpanModel{
+			Status: &config.SpanStatus{Generator: consts.GeneratorRandom, Value: "200,504"},
+			Events: []config.SpanEvent{{Name: "retry", Probability: 0.3}},
+			Links:  config.SpanLinks{Count: 1, Probability: 0.5},
+			Seed:   7,
+		}),
+	)
+
+	run := func() []sdktrace.ReadOnlySpan {
+		recorder, tp := newRecorder()
+		task, err := New(context.Background(), *cfg, WithTracerProvider(tp))
+		require.NoError(t, err)
+		require.NoError(t, task.Execute(context.Background()))
+		return recorder.Ended()
+	}
+
+	first, second := run(), run()
+	require.Len(t, first, 5*cfg.SpansPerTrace())
+	require.Len(t, second, len(first))
+
+	for i := range first {
+		assert.Equal(t, first[i].SpanContext(), second[i].SpanContext())
+		assert.Equal(t, first[i].Parent(), second[i].Parent())
+		assert.Equal(t, first[i].EndTime().Sub(first[i].StartTime()), second[i].EndTime().Sub(second[i].StartTime()))
+		assert.Equal(t, first[i].Attributes(), second[i].Attributes())
+		assert.Equal(t, first[i].Status(), second[i].Status())
+		assert.Equal(t, len(first[i].Events()), len(second[i].Events()))
+		assert.Equal(t, first[i].Links(), second[i].Links())
+	}
+}
+
+func TestTraceTask_Reseed(t *testing.T) {
+	cfg := config.NewTraceTask(
+		config.WithTraceRate(time.Millisecond),
+		config.WithTraceCount(5),
+		config.WithTraceGenerator(consts.GeneratorRandom),
+		config.WithTraceValue("10,100"),
+		config.WithSpanModel(config.SpanModel{
+			Status: &config.SpanStatus{Generator: consts.GeneratorRandom, Value: "200,504"},
+			Seed:   7,
+		}),
+	)
+
+	recorder, tp := newRecorder()
+	task, err := New(context.Background(), *cfg, WithTracerProvider(tp))
+	require.NoError(t, err)
+
+	run := func(iteration int) []sdktrace.ReadOnlySpan {
+		before := len(recorder.Ended())
+		require.NoError(t, task.Execute(runner.WithIteration(context.Background(), iteration)))
+		return recorder.Ended()[before:]
+	}
+
+	first, retried, next := run(1), run(1), run(2)
+	require.Len(t, retried, len(first))
+	for i := range first {
+		assert.Equal(t, first[i].SpanContext(), retried[i].SpanContext(), "executing again replays the iteration")
+		assert.Equal(t, first[i].EndTime().Sub(first[i].StartTime()), retried[i].EndTime().Sub(retried[i].StartTime()))
+		assert.Equal(t, first[i].Attributes(), retried[i].Attributes())
+	}
+	assert.NotEqual(t, first[0].SpanContext().TraceID(), next[0].SpanContext().TraceID(), "iterations draw other traces")
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	cfg := config.NewTraceTask(config.WithDepth(0))
+	_, err := New(context.Background(), *cfg)
+	assert.Error(t, err)
+}